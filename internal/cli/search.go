@@ -0,0 +1,467 @@
+// Package cli implements the non-interactive commands of job-aggr.
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/config"
+	"github.com/brandoyts/job-aggr/internal/dedup"
+	"github.com/brandoyts/job-aggr/internal/export"
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/aggregator"
+	"github.com/brandoyts/job-aggr/internal/service/browser"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+	"github.com/brandoyts/job-aggr/internal/service/scraper/spec"
+	"github.com/brandoyts/job-aggr/internal/store"
+)
+
+// Exit codes of the search command.
+const (
+	ExitOK      = 0 // every source succeeded
+	ExitFailure = 1 // no source succeeded, or fewer than --require
+	ExitUsage   = 2 // invalid flags or config, or no source to search
+	ExitPartial = 3 // results were written but some sources failed
+)
+
+// searchOptions holds the parsed flags of the search command.
+type searchOptions struct {
+	criteria   model.SearchCriteria
+	sources    []string
+	format     string
+	output     string
+	require    int
+	maxPages   int
+	maxResults int
+	timeout    time.Duration
+	enrich     int
+	dedup      bool
+	db         string
+	save       string         // name to save the search under
+	saved      string         // name of the saved search to run
+	statuses   []store.Status // only output jobs with these statuses, all when empty
+	config     string
+	set        map[string]bool // flags given on the command line, which win over the config file
+	list       bool
+}
+
+// Search runs the search command: it scrapes the requested sources without
+// the TUI and writes the jobs to stdout or --output. Progress and errors go to stderr.
+// It returns the process exit code.
+func Search(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) int {
+	opts, err := parseSearchFlags(args, stderr)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+		return ExitUsage
+	}
+
+	cfg, err := loadConfig(opts.config)
+	if err != nil {
+		fmt.Fprintln(stderr, "Error:", err)
+		return ExitUsage
+	}
+	opts.applyConfig(cfg)
+
+	if opts.list {
+		writeSources(stdout, cfg)
+		return ExitOK
+	}
+
+	var repo store.Repository
+	if opts.db != "" {
+		db, err := store.Open(opts.db)
+		if err != nil {
+			fmt.Fprintln(stderr, "Warning: jobs will not be saved:", err)
+		} else {
+			defer db.Close()
+			repo = db
+		}
+	}
+
+	if code := applySavedSearch(ctx, &opts, repo, stderr); code != ExitOK {
+		return code
+	}
+
+	pool := browser.NewPool(cfg.Browser.Options())
+	defer pool.Close()
+
+	scrapers, err := newScrapers(pool, opts, cfg)
+	if err != nil {
+		fmt.Fprintln(stderr, "Error:", err)
+		return ExitUsage
+	}
+
+	return runSearch(ctx, opts, scrapers, repo, stdout, stderr)
+}
+
+// applySavedSearch loads the criteria of --saved and saves them under --save.
+func applySavedSearch(ctx context.Context, opts *searchOptions, repo store.Repository, stderr io.Writer) int {
+	if repo == nil && (opts.save != "" || opts.saved != "" || len(opts.statuses) > 0) {
+		fmt.Fprintln(stderr, "Error: --save, --saved and --status need the job database, see --db")
+		return ExitUsage
+	}
+
+	if opts.saved != "" {
+		criteria, err := repo.SavedSearch(ctx, opts.saved)
+		if err != nil {
+			fmt.Fprintln(stderr, "Error:", err)
+			return ExitUsage
+		}
+		opts.criteria = criteria
+	}
+
+	if opts.save != "" {
+		if err := repo.SaveSearch(ctx, opts.save, opts.criteria); err != nil {
+			fmt.Fprintln(stderr, "Error:", err)
+			return ExitFailure
+		}
+	}
+
+	return ExitOK
+}
+
+func parseSearchFlags(args []string, stderr io.Writer) (searchOptions, error) {
+	var opts searchOptions
+	var sources, postedWithin, workplace, jobType, experience, sort, statuses string
+
+	fs := flag.NewFlagSet("search", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: job-aggr search --query <query> [flags]")
+		fmt.Fprintln(stderr)
+		fs.PrintDefaults()
+	}
+
+	fs.StringVar(&opts.criteria.Query, "query", "", "job title or keywords (required)")
+	fs.StringVar(&opts.criteria.Location, "location", "", "city, region or country")
+	fs.StringVar(&sources, "sources", "", "comma-separated list of sources, the enabled ones of the config file by default")
+	fs.BoolVar(&opts.list, "list-sources", false, "list the available sources and exit")
+	fs.StringVar(&opts.format, "format", "text", "output format: "+strings.Join(export.Names(), ", "))
+	fs.StringVar(&opts.output, "output", "", "write to this file instead of stdout, the format defaults to its extension")
+	fs.IntVar(&opts.require, "require", 0, "fail unless at least this many sources succeed")
+	fs.StringVar(&workplace, "workplace", "", "on-site, hybrid or remote")
+	fs.IntVar(&opts.criteria.RadiusMiles, "radius", 0, "search radius in miles")
+	fs.StringVar(&postedWithin, "posted-within", "", "only jobs posted within this period, e.g. 24h, 3d, 7d")
+	fs.StringVar(&jobType, "job-type", "", "full-time, part-time, contract, temporary or internship")
+	fs.StringVar(&experience, "experience", "", "internship, entry, associate, mid-senior, director or executive")
+	fs.IntVar(&opts.criteria.MinSalary, "min-salary", 0, "minimum yearly salary")
+	fs.StringVar(&sort, "sort", "", "relevance or date")
+	fs.IntVar(&opts.maxPages, "max-pages", 0, "result pages to read per source, overrides the config file")
+	fs.IntVar(&opts.maxResults, "max-results", 0, "jobs to return per source, unlimited when zero, overrides the config file")
+	fs.DurationVar(&opts.timeout, "timeout", 0, "timeout per source, overrides the config file")
+	fs.IntVar(&opts.enrich, "enrich", 0, "visit job detail pages with this many pages in parallel")
+	fs.BoolVar(&opts.dedup, "dedup", true, "merge jobs listed on more than one source")
+	fs.StringVar(&opts.config, "config", "", "config file, defaults to $"+config.EnvPath+" or job-aggr/config.yaml in the user config dir")
+	defaultDB, _ := store.DefaultPath()
+	fs.StringVar(&opts.db, "db", defaultDB, "SQLite database the jobs are saved to, empty to disable")
+	fs.StringVar(&opts.save, "save", "", "save this search under a name")
+	fs.StringVar(&opts.saved, "saved", "", "run the search saved under this name, criteria flags are ignored")
+	fs.StringVar(&statuses, "status", "", "only output jobs that are new, open or disappeared since the previous run, comma-separated")
+
+	if err := fs.Parse(args); err != nil {
+		return opts, err
+	}
+
+	opts.set = map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { opts.set[f.Name] = true })
+
+	if opts.criteria.Query == "" && opts.saved == "" && !opts.list {
+		fmt.Fprintln(stderr, "Error: --query is required")
+		fs.Usage()
+		return opts, errors.New("missing query")
+	}
+
+	// Without an explicit --format, the output file extension picks the format.
+	if opts.output != "" && !opts.set["format"] {
+		if _, err := export.ForFile(opts.output); err == nil {
+			opts.format = ""
+		}
+	}
+
+	if _, err := opts.exporter(); err != nil {
+		fmt.Fprintln(stderr, "Error:", err)
+		return opts, err
+	}
+
+	if postedWithin != "" {
+		d, err := parseAge(postedWithin)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: invalid --posted-within %q\n", postedWithin)
+			return opts, err
+		}
+		opts.criteria.PostedWithin = d
+	}
+
+	var err error
+	if opts.criteria.Workplace, err = parseChoice(stderr, "workplace", workplace, model.WorkplaceTypes); err != nil {
+		return opts, err
+	}
+	if opts.criteria.JobType, err = parseChoice(stderr, "job-type", jobType, model.JobTypes); err != nil {
+		return opts, err
+	}
+	if opts.criteria.Experience, err = parseChoice(stderr, "experience", experience, model.ExperienceLevels); err != nil {
+		return opts, err
+	}
+	if opts.criteria.Sort, err = parseChoice(stderr, "sort", sort, model.SortOrders); err != nil {
+		return opts, err
+	}
+
+	for _, s := range strings.Split(sources, ",") {
+		if s = strings.TrimSpace(strings.ToLower(s)); s != "" {
+			opts.sources = append(opts.sources, s)
+		}
+	}
+
+	for _, s := range strings.Split(statuses, ",") {
+		if strings.TrimSpace(s) == "" {
+			continue
+		}
+		status, err := parseChoice(stderr, "status", s, store.Statuses)
+		if err != nil {
+			return opts, err
+		}
+		opts.statuses = append(opts.statuses, status)
+	}
+
+	return opts, nil
+}
+
+// parseChoice parses the value of a flag that takes one of valid, or nothing.
+// Other values are reported to stderr along with the valid ones.
+func parseChoice[T ~string](stderr io.Writer, name, value string, valid []T) (T, error) {
+	choice := T(strings.TrimSpace(strings.ToLower(value)))
+	if choice == "" || slices.Contains(valid, choice) {
+		return choice, nil
+	}
+
+	names := make([]string, len(valid))
+	for i, v := range valid {
+		names[i] = string(v)
+	}
+	fmt.Fprintf(stderr, "Error: invalid --%s %q, must be one of %s\n", name, value, strings.Join(names, ", "))
+	return "", fmt.Errorf("invalid %s", name)
+}
+
+// loadConfig loads the config file given by --config, $JOB_AGGR_CONFIG or the default location.
+func loadConfig(flagPath string) (config.Config, error) {
+	path, explicit, err := config.Path(flagPath)
+	if err != nil {
+		return config.Config{}, err
+	}
+
+	// Sources described by spec files must be known before the config file names them.
+	if err := spec.RegisterDir(config.SpecsDir(path)); err != nil {
+		return config.Config{}, err
+	}
+	return config.Load(path, explicit, scraper.Names())
+}
+
+// applyConfig uses the sources enabled in the config file unless --sources was given.
+// Sources that need settings, like the companies to read from, are only used once configured.
+func (o *searchOptions) applyConfig(cfg config.Config) {
+	if !o.set["sources"] {
+		o.sources = scraper.Enabled(cfg)
+	}
+}
+
+// exporter returns the exporter for --format, or for the --output extension when no format is set.
+func (o searchOptions) exporter() (export.Exporter, error) {
+	if o.format == "" {
+		return export.ForFile(o.output)
+	}
+	return export.Get(o.format)
+}
+
+// parseAge parses a duration that may also be given in days, e.g. "3d".
+func parseAge(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(s)
+}
+
+// newScrapers creates the scrapers of --sources, configured by the config file
+// and the flags that were given.
+func newScrapers(pool *browser.Pool, opts searchOptions, cfg config.Config) ([]aggregator.JobScraper, error) {
+	var scrapers []aggregator.JobScraper
+
+	for _, name := range opts.sources {
+		src := cfg.Source(name)
+		if opts.set["timeout"] {
+			src.Timeout = opts.timeout
+		}
+		if opts.set["max-pages"] {
+			src.MaxPages = opts.maxPages
+		}
+		if opts.set["max-results"] {
+			src.MaxResults = opts.maxResults
+		}
+
+		s, err := scraper.New(name, pool, src)
+		if err != nil {
+			return nil, err
+		}
+		scrapers = append(scrapers, s)
+	}
+
+	return scrapers, nil
+}
+
+// writeSources lists the registered sources with their capabilities.
+func writeSources(w io.Writer, cfg config.Config) {
+	for _, src := range scraper.Sources() {
+		state := ""
+		switch {
+		case !cfg.Source(src.Name).IsEnabled():
+			state = " (disabled)"
+		case !src.Usable(cfg.Source(src.Name)):
+			state = " (not configured)"
+		case src.OptIn && cfg.Source(src.Name).Enabled == nil:
+			state = " (opt-in)"
+		}
+
+		fmt.Fprintf(w, "%-12s %-16s %s%s\n", src.Name, src.Label, strings.Join(src.Capabilities.List(), ", "), state)
+	}
+}
+
+func runSearch(ctx context.Context, opts searchOptions, scrapers []aggregator.JobScraper, repo store.Repository, stdout io.Writer, stderr io.Writer) int {
+	if len(scrapers) == 0 {
+		fmt.Fprintln(stderr, "Error: no source is available: pick one with --sources, or enable and configure one in the config file, see --list-sources")
+		return ExitUsage
+	}
+
+	aggrOpts := []aggregator.Option{aggregator.WithPolicy(aggregator.BestEffort)}
+	if opts.require > 0 {
+		aggrOpts = []aggregator.Option{aggregator.WithPolicy(aggregator.RequireAtLeast(opts.require))}
+	}
+	if opts.enrich > 0 {
+		aggrOpts = append(aggrOpts, aggregator.WithEnrichment(opts.enrich))
+	}
+	if opts.dedup {
+		aggrOpts = append(aggrOpts, aggregator.WithDedup())
+	}
+	if repo != nil {
+		aggrOpts = append(aggrOpts, aggregator.WithRepository(repo))
+	}
+
+	aggr := aggregator.NewAggregatorServiceWithOptions(scrapers, aggrOpts...)
+
+	result, err := aggr.Aggregate(ctx, opts.criteria)
+	if result != nil {
+		writeReports(stderr, result)
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, "Error:", err)
+		return ExitFailure
+	}
+
+	if result.StoreErr != nil {
+		fmt.Fprintln(stderr, "Warning: jobs were not saved:", result.StoreErr)
+	}
+
+	if result.Succeeded() == 0 {
+		return ExitFailure
+	}
+
+	jobs := result.Jobs
+	if len(opts.statuses) > 0 {
+		if jobs, err = jobsWithStatus(ctx, repo, opts, result, stderr); err != nil {
+			fmt.Fprintln(stderr, "Error:", err)
+			return ExitFailure
+		}
+	}
+
+	if err := writeJobs(opts, stdout, jobs); err != nil {
+		fmt.Fprintln(stderr, "Error:", err)
+		return ExitFailure
+	}
+
+	if len(result.Failed()) > 0 {
+		return ExitPartial
+	}
+
+	return ExitOK
+}
+
+func writeReports(w io.Writer, result *aggregator.Result) {
+	for _, report := range result.Reports {
+		switch {
+		case report.Failed():
+			fmt.Fprintf(w, "%s: failed after %.1fs: %v\n", report.Source, report.Duration.Seconds(), report.Err)
+		default:
+			fmt.Fprintf(w, "%s: %d job(s) in %.1fs\n", report.Source, report.Jobs, report.Duration.Seconds())
+		}
+
+		if len(report.Unsupported) > 0 {
+			fmt.Fprintf(w, "%s: ignored criteria: %s\n", report.Source, strings.Join(report.Unsupported, ", "))
+		}
+	}
+}
+
+// jobsWithStatus compares this run with the previous run of the same search and
+// returns the jobs with one of the requested statuses.
+func jobsWithStatus(ctx context.Context, repo store.Repository, opts searchOptions, result *aggregator.Result, stderr io.Writer) ([]model.Job, error) {
+	if repo == nil || result.StoreErr != nil {
+		return nil, errors.New("the jobs of this run were not saved, cannot compare with the previous run")
+	}
+
+	runJobs, err := repo.LastRun(ctx, opts.criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := map[store.Status]int{}
+	var jobs []model.Job
+	for _, job := range runJobs {
+		counts[job.Status]++
+		if slices.Contains(opts.statuses, job.Status) {
+			jobs = append(jobs, job.Job)
+		}
+	}
+
+	fmt.Fprintf(stderr, "since the previous run: %d new, %d open, %d disappeared\n",
+		counts[store.StatusNew], counts[store.StatusOpen], counts[store.StatusDisappeared])
+
+	if opts.dedup {
+		jobs = dedup.Merge(jobs)
+	}
+
+	return jobs, nil
+}
+
+func writeJobs(opts searchOptions, stdout io.Writer, jobs []model.Job) error {
+	exporter, err := opts.exporter()
+	if err != nil {
+		return err
+	}
+
+	if opts.output == "" {
+		return exporter.Export(stdout, jobs)
+	}
+
+	f, err := os.Create(opts.output)
+	if err != nil {
+		return err
+	}
+
+	if err := exporter.Export(f, jobs); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
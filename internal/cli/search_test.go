@@ -0,0 +1,309 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/config"
+	"github.com/brandoyts/job-aggr/internal/mocks"
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/aggregator"
+	_ "github.com/brandoyts/job-aggr/internal/service/scraper/sources"
+	"github.com/brandoyts/job-aggr/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestParseSearchFlags tests that flags are translated into search criteria
+func TestParseSearchFlags(t *testing.T) {
+	var stderr bytes.Buffer
+
+	opts, err := parseSearchFlags([]string{
+		"--query", "golang",
+		"--location", "Remote",
+		"--sources", "Indeed, linkedin",
+		"--format", "json",
+		"--posted-within", "3d",
+		"--workplace", "remote",
+	}, &stderr)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "golang", opts.criteria.Query)
+	assert.Equal(t, "Remote", opts.criteria.Location)
+	assert.Equal(t, []string{"indeed", "linkedin"}, opts.sources)
+	assert.Equal(t, "json", opts.format)
+	assert.Equal(t, 72*time.Hour, opts.criteria.PostedWithin)
+	assert.Equal(t, model.WorkplaceRemote, opts.criteria.Workplace)
+	assert.True(t, opts.dedup, "duplicates are merged by default")
+}
+
+// TestParseSearchFlagsOutputFormat tests that the output file extension picks the format unless --format is set
+func TestParseSearchFlagsOutputFormat(t *testing.T) {
+	var stderr bytes.Buffer
+
+	opts, err := parseSearchFlags([]string{"--query", "golang", "--output", "jobs.csv"}, &stderr)
+	assert.NoError(t, err)
+	assert.Empty(t, opts.format)
+
+	opts, err = parseSearchFlags([]string{"--query", "golang", "--output", "jobs.txt", "--format", "jsonl"}, &stderr)
+	assert.NoError(t, err)
+	assert.Equal(t, "jsonl", opts.format)
+
+	_, err = parseSearchFlags([]string{"--query", "golang", "--format", "xml"}, &stderr)
+	assert.Error(t, err)
+}
+
+// TestParseSearchFlagsInvalidChoice tests that flags taking one of a set of values reject any other
+func TestParseSearchFlagsInvalidChoice(t *testing.T) {
+	tests := []struct {
+		flag, value, want string
+	}{
+		{"--workplace", "remotely", `invalid --workplace "remotely", must be one of on-site, hybrid, remote`},
+		{"--job-type", "freelance", `invalid --job-type "freelance", must be one of full-time, part-time, contract, temporary, internship`},
+		{"--experience", "senior", `invalid --experience "senior", must be one of internship, entry, associate, mid-senior, director, executive`},
+		{"--sort", "salary", `invalid --sort "salary", must be one of relevance, date`},
+		{"--status", "new,closed", `invalid --status "closed", must be one of new, open, disappeared`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.flag, func(t *testing.T) {
+			var stderr bytes.Buffer
+
+			_, err := parseSearchFlags([]string{"--query", "golang", tt.flag, tt.value}, &stderr)
+
+			assert.Error(t, err)
+			assert.Contains(t, stderr.String(), tt.want)
+		})
+	}
+
+	var stderr bytes.Buffer
+	opts, err := parseSearchFlags([]string{"--query", "golang", "--job-type", "Full-Time", "--sort", "date"}, &stderr)
+	assert.NoError(t, err)
+	assert.Equal(t, model.JobTypeFullTime, opts.criteria.JobType)
+	assert.Equal(t, model.SortDate, opts.criteria.Sort)
+}
+
+// TestParseSearchFlagsRequiresQuery tests that a query is mandatory
+func TestParseSearchFlagsRequiresQuery(t *testing.T) {
+	var stderr bytes.Buffer
+
+	_, err := parseSearchFlags([]string{"--location", "Remote"}, &stderr)
+
+	assert.Error(t, err)
+	assert.Contains(t, stderr.String(), "--query is required")
+}
+
+// TestRunSearchExitCodes tests the exit code for full success, partial failure and total failure
+func TestRunSearchExitCodes(t *testing.T) {
+	criteria := model.SearchCriteria{Query: "golang"}
+	opts := searchOptions{criteria: criteria, format: "json"}
+
+	ok := mocks.NewJobScraper(t)
+	ok.On("Fetch", mock.Anything, criteria).Return([]model.Job{{ID: "1", Title: "Go Dev", Source: "Indeed"}}, nil)
+
+	failing := mocks.NewJobScraper(t)
+	failing.On("Fetch", mock.Anything, criteria).Return(nil, errors.New("blocked"))
+
+	tests := []struct {
+		name     string
+		scrapers []aggregator.JobScraper
+		want     int
+		jobs     int
+	}{
+		{"all succeeded", []aggregator.JobScraper{ok}, ExitOK, 1},
+		{"partial failure", []aggregator.JobScraper{ok, failing}, ExitPartial, 1},
+		{"total failure", []aggregator.JobScraper{failing}, ExitFailure, -1},
+	}
+
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+
+		code := runSearch(context.Background(), opts, tt.scrapers, nil, &stdout, &stderr)
+		assert.Equal(t, tt.want, code, tt.name)
+
+		if tt.jobs < 0 {
+			assert.Empty(t, stdout.String(), tt.name)
+			continue
+		}
+
+		var jobs []model.Job
+		assert.NoError(t, json.Unmarshal(stdout.Bytes(), &jobs), tt.name)
+		assert.Len(t, jobs, tt.jobs, tt.name)
+	}
+}
+
+// TestRunSearchRequire tests that --require turns a partial failure into a total failure
+func TestRunSearchRequire(t *testing.T) {
+	criteria := model.SearchCriteria{Query: "golang"}
+
+	ok := mocks.NewJobScraper(t)
+	ok.On("Fetch", mock.Anything, criteria).Return([]model.Job{{ID: "1"}}, nil)
+
+	failing := mocks.NewJobScraper(t)
+	failing.On("Fetch", mock.Anything, criteria).Return(nil, errors.New("timeout"))
+
+	var stdout, stderr bytes.Buffer
+	code := runSearch(context.Background(), searchOptions{criteria: criteria, format: "text", require: 2},
+		[]aggregator.JobScraper{ok, failing}, nil, &stdout, &stderr)
+
+	assert.Equal(t, ExitFailure, code)
+	assert.Empty(t, stdout.String())
+	assert.Contains(t, stderr.String(), "source #2: failed")
+}
+
+// TestRunSearchWithoutSources tests that a search without any source available is a usage error
+func TestRunSearchWithoutSources(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := runSearch(context.Background(), searchOptions{criteria: model.SearchCriteria{Query: "golang"}, format: "text"},
+		nil, nil, &stdout, &stderr)
+
+	assert.Equal(t, ExitUsage, code)
+	assert.Empty(t, stdout.String())
+	assert.Contains(t, stderr.String(), "no source is available")
+}
+
+// TestRunSearchSavesJobs tests that the jobs are saved to the store
+func TestRunSearchSavesJobs(t *testing.T) {
+	criteria := model.SearchCriteria{Query: "golang"}
+	jobs := []model.Job{{ID: "1", Title: "Go Dev", Source: "Indeed"}}
+
+	scraper := mocks.NewJobScraper(t)
+	scraper.On("Fetch", mock.Anything, criteria).Return(jobs, nil)
+
+	repo := mocks.NewRepository(t)
+	repo.On("RecordRun", mock.Anything, criteria, []model.SourceRun{{Source: "source #1", Jobs: jobs}}).Return(errors.New("database is locked"))
+
+	var stdout, stderr bytes.Buffer
+	code := runSearch(context.Background(), searchOptions{criteria: criteria, format: "text"},
+		[]aggregator.JobScraper{scraper}, repo, &stdout, &stderr)
+
+	assert.Equal(t, ExitOK, code, "a store failure doesn't fail the search")
+	assert.Contains(t, stdout.String(), "Go Dev")
+	assert.Contains(t, stderr.String(), "jobs were not saved: database is locked")
+}
+
+// TestRunSearchStatusFilter tests that --status only outputs jobs with the requested statuses
+func TestRunSearchStatusFilter(t *testing.T) {
+	criteria := model.SearchCriteria{Query: "golang"}
+	jobs := []model.Job{{ID: "1", Title: "Go Dev", Source: "Indeed"}, {ID: "2", Title: "Gopher", Source: "Indeed"}}
+
+	scraper := mocks.NewJobScraper(t)
+	scraper.On("Fetch", mock.Anything, criteria).Return(jobs, nil)
+
+	repo := mocks.NewRepository(t)
+	repo.On("RecordRun", mock.Anything, criteria, []model.SourceRun{{Source: "source #1", Jobs: jobs}}).Return(nil)
+	repo.On("LastRun", mock.Anything, criteria).Return([]store.RunJob{
+		{StoredJob: store.StoredJob{Job: jobs[0]}, Status: store.StatusOpen},
+		{StoredJob: store.StoredJob{Job: jobs[1]}, Status: store.StatusNew},
+		{StoredJob: store.StoredJob{Job: model.Job{ID: "0", Title: "Filled", Source: "Indeed"}}, Status: store.StatusDisappeared},
+	}, nil)
+
+	var stdout, stderr bytes.Buffer
+	code := runSearch(context.Background(),
+		searchOptions{criteria: criteria, format: "text", statuses: []store.Status{store.StatusNew, store.StatusDisappeared}},
+		[]aggregator.JobScraper{scraper}, repo, &stdout, &stderr)
+
+	assert.Equal(t, ExitOK, code)
+	assert.Contains(t, stdout.String(), "Gopher")
+	assert.Contains(t, stdout.String(), "Filled")
+	assert.NotContains(t, stdout.String(), "Go Dev")
+	assert.Contains(t, stderr.String(), "1 new, 1 open, 1 disappeared")
+}
+
+// TestParseSearchFlagsStatus tests parsing --status and running a saved search without --query
+func TestParseSearchFlagsStatus(t *testing.T) {
+	var stderr bytes.Buffer
+
+	opts, err := parseSearchFlags([]string{"--saved", "daily", "--status", "new, Disappeared"}, &stderr)
+	assert.NoError(t, err)
+	assert.Equal(t, "daily", opts.saved)
+	assert.Equal(t, []store.Status{store.StatusNew, store.StatusDisappeared}, opts.statuses)
+
+	_, err = parseSearchFlags([]string{"--query", "golang", "--status", "closed"}, &stderr)
+	assert.Error(t, err)
+}
+
+// TestApplySavedSearch tests that --saved loads the criteria and --save stores them
+func TestApplySavedSearch(t *testing.T) {
+	saved := model.SearchCriteria{Query: "golang", Workplace: model.WorkplaceRemote}
+
+	repo := mocks.NewRepository(t)
+	repo.On("SavedSearch", mock.Anything, "daily").Return(saved, nil)
+	repo.On("SaveSearch", mock.Anything, "copy", saved).Return(nil)
+
+	var stderr bytes.Buffer
+	opts := searchOptions{saved: "daily", save: "copy"}
+
+	assert.Equal(t, ExitOK, applySavedSearch(context.Background(), &opts, repo, &stderr))
+	assert.Equal(t, saved, opts.criteria)
+
+	opts = searchOptions{saved: "daily"}
+	assert.Equal(t, ExitUsage, applySavedSearch(context.Background(), &opts, nil, &stderr))
+}
+
+// TestApplyConfig tests that the config file picks the sources unless --sources is given
+func TestApplyConfig(t *testing.T) {
+	var stderr bytes.Buffer
+	cfg, err := config.Parse([]byte("sources:\n  linkedin:\n    enabled: false\n"))
+	assert.NoError(t, err)
+
+	opts, err := parseSearchFlags([]string{"--query", "golang"}, &stderr)
+	assert.NoError(t, err)
+	opts.applyConfig(cfg)
+	assert.Equal(t, []string{"indeed"}, opts.sources)
+
+	opts, err = parseSearchFlags([]string{"--query", "golang", "--sources", "linkedin"}, &stderr)
+	assert.NoError(t, err)
+	opts.applyConfig(cfg)
+	assert.Equal(t, []string{"linkedin"}, opts.sources)
+}
+
+// TestSearchInvalidConfig tests that an invalid config file is a usage error naming the setting
+func TestSearchInvalidConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("sources:\n  indeed:\n    timeout: -1s\n"), 0o644))
+
+	var stdout, stderr bytes.Buffer
+	code := Search(context.Background(), []string{"--query", "golang", "--config", path, "--db", ""}, &stdout, &stderr)
+
+	assert.Equal(t, ExitUsage, code)
+	assert.Contains(t, stderr.String(), "sources.indeed.timeout: must not be negative")
+}
+
+// TestSearchListSources tests that --list-sources prints the registered sources without searching
+func TestSearchListSources(t *testing.T) {
+	t.Setenv(config.EnvPath, "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	var stdout, stderr bytes.Buffer
+	code := Search(context.Background(), []string{"--list-sources", "--db", ""}, &stdout, &stderr)
+
+	assert.Equal(t, ExitOK, code)
+	assert.Contains(t, stdout.String(), "indeed")
+	assert.Contains(t, stdout.String(), "LinkedIn")
+	assert.Contains(t, stdout.String(), "remote filter")
+}
+
+// TestSearchSpecSources tests that the spec files next to the config file become sources
+func TestSearchSpecSources(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("sources:\n  test-cli-spec:\n    max_pages: 2\n"), 0o644))
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "specs"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "specs", "jobs.yaml"), []byte(
+		"name: test-cli-spec\nlabel: Spec Jobs\nbase_url: https://example.com\nsearch_url: /jobs\ncards: li\nfields:\n  title: h2\n",
+	), 0o644))
+
+	var stdout, stderr bytes.Buffer
+	code := Search(context.Background(), []string{"--list-sources", "--config", path, "--db", ""}, &stdout, &stderr)
+
+	assert.Equal(t, ExitOK, code, stderr.String())
+	assert.Contains(t, stdout.String(), "Spec Jobs")
+}
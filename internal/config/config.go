@@ -0,0 +1,183 @@
+// Package config loads the job-aggr configuration file: which sources are enabled
+// and how they are scraped, how the browser is launched, and the defaults of the TUI.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/service/browser"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPath is the environment variable that overrides the location of the config file.
+const EnvPath = "JOB_AGGR_CONFIG"
+
+// Config is the content of the config file. Everything is optional.
+type Config struct {
+	Sources  map[string]Source `yaml:"sources"`
+	Browser  Browser           `yaml:"browser"`
+	Defaults Defaults          `yaml:"defaults"`
+	TUI      TUI               `yaml:"tui"`
+}
+
+// Source configures a single source, keyed by its name in Config.Sources.
+type Source struct {
+	Enabled    *bool         `yaml:"enabled"`     // sources are enabled unless set to false
+	Timeout    time.Duration `yaml:"timeout"`     // bounds a whole search, the source's default when zero
+	MaxPages   int           `yaml:"max_pages"`   // result pages to read, the source's default when zero
+	MaxResults int           `yaml:"max_results"` // jobs to return at most, unlimited when zero
+	BaseURL    string        `yaml:"base_url"`    // site to search, e.g. a regional one
+	Companies  []string      `yaml:"companies"`   // boards to read for job board APIs, e.g. Greenhouse board tokens
+	URLs       []string      `yaml:"urls"`        // pages to read for sources that aren't searched, e.g. career pages
+	Sitemaps   []string      `yaml:"sitemaps"`    // sitemaps listing more such pages
+	Feeds      []Feed        `yaml:"feeds"`       // RSS and Atom feeds to read for the feeds source
+}
+
+// Feed configures an RSS or Atom feed and how its items map to jobs.
+type Feed struct {
+	URL  string `yaml:"url"`
+	Name string `yaml:"name"` // shown as the source of its jobs, "Feeds" when empty
+
+	// TitlePattern splits item titles with a regular expression whose named groups
+	// "company", "title" and "location" give those fields, e.g.
+	// `^(?P<company>[^:]+): (?P<title>.+)$`. Titles it doesn't match are kept whole.
+	TitlePattern string `yaml:"title_pattern"`
+
+	Company  string `yaml:"company"`  // company of every item, for a company's own feed
+	Location string `yaml:"location"` // location of items that don't give one
+
+	// Categories tells what item categories are: "tags" (the default),
+	// "department" or "ignore".
+	Categories string `yaml:"categories"`
+}
+
+// FeedCategories are the values Feed.Categories accepts.
+var FeedCategories = []string{"tags", "department", "ignore"}
+
+// IsEnabled reports whether the source should be searched.
+func (s Source) IsEnabled() bool {
+	return s.Enabled == nil || *s.Enabled
+}
+
+// Browser configures the shared headless browser.
+type Browser struct {
+	Bin       string `yaml:"bin"`
+	Headless  *bool  `yaml:"headless"`
+	NoSandbox *bool  `yaml:"no_sandbox"`
+	Proxy     string `yaml:"proxy"`
+	MaxPages  int    `yaml:"max_pages"`
+}
+
+// Options returns the browser pool options, starting from browser.DefaultOptions.
+func (b Browser) Options() browser.Options {
+	opts := browser.DefaultOptions()
+	opts.Bin = b.Bin
+	opts.Proxy = b.Proxy
+	if b.Headless != nil {
+		opts.Headless = *b.Headless
+	}
+	if b.NoSandbox != nil {
+		opts.NoSandbox = *b.NoSandbox
+	}
+	if b.MaxPages > 0 {
+		opts.MaxPages = b.MaxPages
+	}
+	return opts
+}
+
+// Defaults are the values the search form starts with.
+type Defaults struct {
+	Query    string `yaml:"query"`
+	Location string `yaml:"location"`
+}
+
+// TUI configures the layout of the terminal UI.
+type TUI struct {
+	TableHeight int            `yaml:"table_height"`
+	Columns     map[string]int `yaml:"columns"` // column widths by column name, e.g. title: 60
+}
+
+// Path returns the config file to load: flagPath when set, then $JOB_AGGR_CONFIG,
+// then config.yaml in the job-aggr directory of the user config dir
+// ($XDG_CONFIG_HOME/job-aggr/config.yaml on Linux). explicit reports whether the
+// file was asked for, in which case it must exist.
+func Path(flagPath string) (path string, explicit bool, err error) {
+	if flagPath != "" {
+		return flagPath, true, nil
+	}
+	if env := os.Getenv(EnvPath); env != "" {
+		return env, true, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", false, err
+	}
+	return filepath.Join(dir, "job-aggr", "config.yaml"), false, nil
+}
+
+// SpecsDir returns the directory of the scraper spec files going with the config
+// file at path: specs, next to it.
+func SpecsDir(path string) string {
+	return filepath.Join(filepath.Dir(path), "specs")
+}
+
+// Load reads and validates the config file at path. A missing file yields an empty
+// config unless it was asked for explicitly. Errors mention the file and, for
+// validation errors, the offending setting.
+func Load(path string, explicit bool, sources []string) (Config, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && !explicit {
+		return Config{}, nil
+	}
+	if err != nil {
+		return Config{}, fmt.Errorf("config: %w", err)
+	}
+
+	cfg, err := Parse(b)
+	if err != nil {
+		return Config{}, fmt.Errorf("config %s: %w", path, err)
+	}
+
+	if err := cfg.Validate(sources); err != nil {
+		return Config{}, fmt.Errorf("config %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// Parse decodes a YAML config. Unknown settings are rejected so typos don't go unnoticed.
+func Parse(b []byte) (Config, error) {
+	var cfg Config
+
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	dec.KnownFields(true)
+
+	if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return Config{}, err
+	}
+
+	return cfg, nil
+}
+
+// Source returns the settings of the named source.
+func (c Config) Source(name string) Source {
+	return c.Sources[name]
+}
+
+// Enabled returns the sources of available that are not disabled, in the same order.
+func (c Config) Enabled(available []string) []string {
+	var enabled []string
+	for _, name := range available {
+		if c.Source(name).IsEnabled() {
+			enabled = append(enabled, name)
+		}
+	}
+	return enabled
+}
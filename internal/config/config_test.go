@@ -0,0 +1,142 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var sources = []string{"indeed", "linkedin"}
+
+const sample = `
+sources:
+  indeed:
+    timeout: 45s
+    max_pages: 2
+    base_url: https://uk.indeed.com
+  linkedin:
+    enabled: false
+browser:
+  headless: false
+  proxy: 127.0.0.1:8080
+defaults:
+  query: golang
+  location: Berlin
+tui:
+  table_height: 30
+  columns:
+    title: 60
+`
+
+// TestParse tests that every section of the config file is decoded
+func TestParse(t *testing.T) {
+	cfg, err := Parse([]byte(sample))
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate(sources))
+
+	assert.Equal(t, 45*time.Second, cfg.Source("indeed").Timeout)
+	assert.Equal(t, 2, cfg.Source("indeed").MaxPages)
+	assert.Equal(t, "https://uk.indeed.com", cfg.Source("indeed").BaseURL)
+	assert.Equal(t, []string{"indeed"}, cfg.Enabled(sources))
+	assert.Equal(t, "golang", cfg.Defaults.Query)
+	assert.Equal(t, 30, cfg.TUI.TableHeight)
+	assert.Equal(t, 60, cfg.TUI.Columns["title"])
+
+	opts := cfg.Browser.Options()
+	assert.False(t, opts.Headless)
+	assert.True(t, opts.NoSandbox, "unset settings keep their default")
+	assert.Equal(t, "127.0.0.1:8080", opts.Proxy)
+	assert.Equal(t, 4, opts.MaxPages)
+}
+
+// TestParseRejectsUnknownSettings tests that typos are reported with their line
+func TestParseRejectsUnknownSettings(t *testing.T) {
+	_, err := Parse([]byte("browser:\n  headles: true\n"))
+
+	assert.ErrorContains(t, err, "line 2")
+	assert.ErrorContains(t, err, "headles")
+}
+
+// TestParseEmpty tests that an empty file is a valid config
+func TestParseEmpty(t *testing.T) {
+	cfg, err := Parse(nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, sources, cfg.Enabled(sources))
+}
+
+// TestValidate tests that invalid settings are reported by name
+func TestValidate(t *testing.T) {
+	disabled := false
+
+	cfg := Config{
+		Sources: map[string]Source{
+			"indeed": {Enabled: &disabled, Timeout: -time.Second, URLs: []string{"https://example.com/jobs", "/careers"}},
+			"linkedin": {Enabled: &disabled, BaseURL: "www.linkedin.com", Feeds: []Feed{
+				{URL: "https://example.com/jobs.rss", TitlePattern: "^(.+): (.+)$"},
+				{URL: "https://example.com/jobs.atom", TitlePattern: "(?P<title", Categories: "labels"},
+			}},
+			"monster": {},
+		},
+		Browser: Browser{Bin: "/does/not/exist", Proxy: "localhost"},
+		TUI:     TUI{Columns: map[string]int{"salery": 10}},
+	}
+
+	err := cfg.Validate(sources)
+	require.Error(t, err)
+
+	for _, want := range []string{
+		"sources.indeed.timeout: must not be negative",
+		`sources.linkedin.base_url: "www.linkedin.com" is not an http(s) URL`,
+		`sources.indeed.urls[1]: "/careers" is not an http(s) URL`,
+		"sources.linkedin.feeds[0].title_pattern: has none of the groups",
+		"sources.linkedin.feeds[1].title_pattern: error parsing regexp",
+		`sources.linkedin.feeds[1].categories: "labels" is not one of tags, department, ignore`,
+		"sources.monster: unknown source, expected one of indeed, linkedin",
+		"sources: every source is disabled",
+		"browser.bin:",
+		`browser.proxy: "localhost" is neither host:port nor a proxy URL`,
+		"tui.columns.salery: unknown column",
+	} {
+		assert.ErrorContains(t, err, want)
+	}
+}
+
+// TestPath tests that the flag wins over the environment, which wins over the XDG config dir
+func TestPath(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/xdg")
+	t.Setenv(EnvPath, "")
+
+	path, explicit, err := Path("")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/xdg", "job-aggr", "config.yaml"), path)
+	assert.False(t, explicit)
+
+	t.Setenv(EnvPath, "/env.yaml")
+	path, explicit, _ = Path("")
+	assert.Equal(t, "/env.yaml", path)
+	assert.True(t, explicit)
+
+	path, _, _ = Path("/flag.yaml")
+	assert.Equal(t, "/flag.yaml", path)
+}
+
+// TestLoad tests that only a config file that was asked for must exist
+func TestLoad(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "config.yaml")
+
+	_, err := Load(missing, false, sources)
+	assert.NoError(t, err)
+
+	_, err = Load(missing, true, sources)
+	assert.Error(t, err)
+
+	require.NoError(t, os.WriteFile(missing, []byte("sources:\n  indeed:\n    max_pages: -1\n"), 0o644))
+	_, err = Load(missing, false, sources)
+	assert.ErrorContains(t, err, missing)
+	assert.ErrorContains(t, err, "sources.indeed.max_pages")
+}
@@ -0,0 +1,125 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Columns are the columns of the jobs table whose width can be configured.
+var Columns = []string{"status", "title", "company", "location", "salary", "sources", "link"}
+
+// Validate checks the settings that can be wrong without being a YAML error.
+// sources are the names of the available sources. Every problem is reported, one per line.
+func (c Config) Validate(sources []string) error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	names := make([]string, 0, len(c.Sources))
+	for name := range c.Sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		s := c.Sources[name]
+		key := "sources." + name
+
+		if !slices.Contains(sources, name) {
+			fail("%s: unknown source, expected one of %s", key, strings.Join(sources, ", "))
+			continue
+		}
+		if s.Timeout < 0 {
+			fail("%s.timeout: must not be negative", key)
+		}
+		if s.MaxPages < 0 {
+			fail("%s.max_pages: must not be negative", key)
+		}
+		if s.MaxResults < 0 {
+			fail("%s.max_results: must not be negative", key)
+		}
+		if s.BaseURL != "" && !httpURL(s.BaseURL) {
+			fail("%s.base_url: %q is not an http(s) URL", key, s.BaseURL)
+		}
+		for i, u := range s.URLs {
+			if !httpURL(u) {
+				fail("%s.urls[%d]: %q is not an http(s) URL", key, i, u)
+			}
+		}
+		for i, u := range s.Sitemaps {
+			if !httpURL(u) {
+				fail("%s.sitemaps[%d]: %q is not an http(s) URL", key, i, u)
+			}
+		}
+		for i, f := range s.Feeds {
+			feedKey := fmt.Sprintf("%s.feeds[%d]", key, i)
+			if !httpURL(f.URL) {
+				fail("%s.url: %q is not an http(s) URL", feedKey, f.URL)
+			}
+			if f.TitlePattern != "" {
+				if re, err := regexp.Compile(f.TitlePattern); err != nil {
+					fail("%s.title_pattern: %v", feedKey, err)
+				} else if re.SubexpIndex("company") < 0 && re.SubexpIndex("title") < 0 && re.SubexpIndex("location") < 0 {
+					fail("%s.title_pattern: has none of the groups (?P<company>...), (?P<title>...) or (?P<location>...)", feedKey)
+				}
+			}
+			if f.Categories != "" && !slices.Contains(FeedCategories, f.Categories) {
+				fail("%s.categories: %q is not one of %s", feedKey, f.Categories, strings.Join(FeedCategories, ", "))
+			}
+		}
+	}
+
+	if len(c.Sources) > 0 && len(c.Enabled(sources)) == 0 {
+		fail("sources: every source is disabled")
+	}
+
+	if c.Browser.Bin != "" {
+		if info, err := os.Stat(c.Browser.Bin); err != nil {
+			fail("browser.bin: %v", err)
+		} else if info.IsDir() {
+			fail("browser.bin: %s is a directory", c.Browser.Bin)
+		}
+	}
+	if c.Browser.Proxy != "" && !validProxy(c.Browser.Proxy) {
+		fail("browser.proxy: %q is neither host:port nor a proxy URL", c.Browser.Proxy)
+	}
+	if c.Browser.MaxPages < 0 {
+		fail("browser.max_pages: must not be negative")
+	}
+
+	if c.TUI.TableHeight < 0 {
+		fail("tui.table_height: must not be negative")
+	}
+	for name, width := range c.TUI.Columns {
+		if !slices.Contains(Columns, name) {
+			fail("tui.columns.%s: unknown column, expected one of %s", name, strings.Join(Columns, ", "))
+		} else if width < 0 {
+			fail("tui.columns.%s: must not be negative", name)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// httpURL reports whether s is an absolute http or https URL.
+func httpURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// validProxy accepts "host:port" as well as URLs like "socks5://host:port".
+func validProxy(proxy string) bool {
+	if u, err := url.Parse(proxy); err == nil && u.Scheme != "" && u.Host != "" {
+		return true
+	}
+	_, port, err := net.SplitHostPort(proxy)
+	return err == nil && port != ""
+}
@@ -0,0 +1,130 @@
+// Package dedup finds the same job listed on several sources and merges the
+// listings into a single job.
+package dedup
+
+import (
+	"github.com/brandoyts/job-aggr/internal/model"
+)
+
+// minTitleSimilarity is how much two normalized titles must overlap, as the share of
+// words they have in common, for jobs at the same company and location to be merged.
+// Titles must also name the same level, e.g. "senior".
+const minTitleSimilarity = 0.75
+
+// Merge clusters jobs by title, company and location and merges every cluster into
+// its first job, which keeps its position. A job without a location matches jobs at
+// the same company wherever they are. A merged job carries the listings of all
+// its sources in Links, and fields missing from the first listing are filled in from
+// the others. Jobs of the same source are never merged with each other.
+func Merge(jobs []model.Job) []model.Job {
+	type cluster struct {
+		job      model.Job
+		title    []string
+		level    string
+		location string
+		sources  map[string]bool
+	}
+
+	var clusters []*cluster
+	buckets := map[string][]*cluster{} // by company
+
+	for _, job := range jobs {
+		company, location := normalizeCompany(job.Company), normalizeLocation(job.Location)
+		title := normalizeTitle(job.Title)
+		level := titleLevel(title)
+
+		var match *cluster
+		if company != "" && len(title) > 0 {
+			for _, c := range buckets[company] {
+				if c.location != "" && location != "" && c.location != location {
+					continue
+				}
+				if c.level == level && !overlaps(c.sources, job) && similarity(c.title, title) >= minTitleSimilarity {
+					match = c
+					break
+				}
+			}
+		}
+
+		if match != nil {
+			merge(&match.job, job)
+			if match.location == "" {
+				match.location = location
+			}
+			for _, link := range links(job) {
+				match.sources[link.Source] = true
+			}
+			continue
+		}
+
+		c := &cluster{job: job, title: title, level: level, location: location, sources: map[string]bool{}}
+		for _, link := range links(job) {
+			c.sources[link.Source] = true
+		}
+
+		clusters = append(clusters, c)
+		if company != "" {
+			buckets[company] = append(buckets[company], c)
+		}
+	}
+
+	merged := make([]model.Job, len(clusters))
+	for i, c := range clusters {
+		merged[i] = c.job
+	}
+	return merged
+}
+
+// overlaps reports whether job was found on one of sources already.
+func overlaps(sources map[string]bool, job model.Job) bool {
+	for _, link := range links(job) {
+		if sources[link.Source] {
+			return true
+		}
+	}
+	return false
+}
+
+// links returns the listings of a job, which is a single one unless it was merged before.
+func links(job model.Job) []model.JobLink {
+	if len(job.Links) > 0 {
+		return job.Links
+	}
+	return []model.JobLink{{Source: job.Source, ID: job.ID, Url: job.Url}}
+}
+
+// merge adds the listings of dup to job and fills in the details job is missing.
+func merge(job *model.Job, dup model.Job) {
+	job.Links = append(links(*job), links(dup)...)
+
+	if job.Location == "" {
+		job.Location = dup.Location
+	}
+	if job.Salary == "" {
+		job.Salary = dup.Salary
+	}
+	if job.Pay.IsZero() {
+		job.Pay = dup.Pay
+	}
+	if len(dup.Description) > len(job.Description) {
+		job.Description = dup.Description
+	}
+	if job.EmploymentType == "" {
+		job.EmploymentType = dup.EmploymentType
+	}
+	if job.Department == "" {
+		job.Department = dup.Department
+	}
+	if job.Workplace == "" {
+		job.Workplace = dup.Workplace
+	}
+	if job.Regions == "" {
+		job.Regions = dup.Regions
+	}
+	if len(job.Tags) == 0 {
+		job.Tags = dup.Tags
+	}
+	if job.PostedAt.IsZero() || (!dup.PostedAt.IsZero() && dup.PostedAt.Before(job.PostedAt)) {
+		job.PostedAt = dup.PostedAt
+	}
+}
@@ -0,0 +1,136 @@
+package dedup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMergeAcrossSources tests that the same job on two sources becomes one job with both links
+func TestMergeAcrossSources(t *testing.T) {
+	posted := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	jobs := Merge([]model.Job{
+		{ID: "li1", Title: "Senior Go Developer", Company: "Acme, Inc.", Location: "Berlin, Germany", Url: "https://linkedin.com/1", Source: "LinkedIn"},
+		{ID: "x", Title: "Designer", Company: "Other", Location: "Berlin", Source: "LinkedIn"},
+		{ID: "in1", Title: "Sr. Go Developer (m/w/d)", Company: "ACME LLC", Location: "Berlin", Url: "https://indeed.com/1", Source: "Indeed",
+			Salary: "€70,000 a year", Description: "Write Go", PostedAt: posted},
+	})
+
+	require.Len(t, jobs, 2)
+
+	merged := jobs[0]
+	assert.Equal(t, "Senior Go Developer", merged.Title, "the first listing is kept")
+	assert.Equal(t, "€70,000 a year", merged.Salary, "missing details come from the duplicate")
+	assert.Equal(t, "Write Go", merged.Description)
+	assert.Equal(t, posted, merged.PostedAt)
+	assert.Equal(t, []model.JobLink{
+		{Source: "LinkedIn", ID: "li1", Url: "https://linkedin.com/1"},
+		{Source: "Indeed", ID: "in1", Url: "https://indeed.com/1"},
+	}, merged.Links)
+	assert.Equal(t, []string{"LinkedIn", "Indeed"}, merged.Sources())
+
+	assert.Equal(t, "Designer", jobs[1].Title)
+	assert.Empty(t, jobs[1].Links)
+}
+
+// TestMergeKeepsDistinctJobs tests that jobs are only merged when title, company and location match
+func TestMergeKeepsDistinctJobs(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b model.Job
+	}{
+		{
+			name: "same source",
+			a:    model.Job{ID: "1", Title: "Go Developer", Company: "Acme", Location: "Berlin", Source: "Indeed"},
+			b:    model.Job{ID: "2", Title: "Go Developer", Company: "Acme", Location: "Berlin", Source: "Indeed"},
+		},
+		{
+			name: "different company",
+			a:    model.Job{Title: "Go Developer", Company: "Acme", Location: "Berlin", Source: "Indeed"},
+			b:    model.Job{Title: "Go Developer", Company: "Globex", Location: "Berlin", Source: "LinkedIn"},
+		},
+		{
+			name: "different city",
+			a:    model.Job{Title: "Go Developer", Company: "Acme", Location: "Berlin", Source: "Indeed"},
+			b:    model.Job{Title: "Go Developer", Company: "Acme", Location: "Munich", Source: "LinkedIn"},
+		},
+		{
+			name: "different level",
+			a:    model.Job{Title: "Senior Go Developer", Company: "Acme", Location: "Berlin", Source: "Indeed"},
+			b:    model.Job{Title: "Junior Go Developer", Company: "Acme", Location: "Berlin", Source: "LinkedIn"},
+		},
+		{
+			name: "level missing",
+			a:    model.Job{Title: "Senior Go Developer", Company: "Acme", Location: "Berlin", Source: "Indeed"},
+			b:    model.Job{Title: "Go Developer", Company: "Acme", Location: "Berlin", Source: "LinkedIn"},
+		},
+		{
+			name: "different grade",
+			a:    model.Job{Title: "Software Engineer II", Company: "Acme", Location: "Berlin", Source: "Indeed"},
+			b:    model.Job{Title: "Software Engineer III", Company: "Acme", Location: "Berlin", Source: "LinkedIn"},
+		},
+		{
+			name: "different title",
+			a:    model.Job{Title: "Go Developer", Company: "Acme", Location: "Berlin", Source: "Indeed"},
+			b:    model.Job{Title: "Frontend Developer", Company: "Acme", Location: "Berlin", Source: "LinkedIn"},
+		},
+		{
+			name: "unknown company",
+			a:    model.Job{Title: "Go Developer", Location: "Berlin", Source: "Indeed"},
+			b:    model.Job{Title: "Go Developer", Location: "Berlin", Source: "LinkedIn"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Len(t, Merge([]model.Job{tt.a, tt.b}), 2)
+		})
+	}
+}
+
+// TestMergeUnknownLocation tests that a job without a location matches the same job anywhere
+func TestMergeUnknownLocation(t *testing.T) {
+	jobs := Merge([]model.Job{
+		{ID: "1", Title: "Go Developer", Company: "Acme", Source: "Greenhouse"},
+		{ID: "2", Title: "Go Developer", Company: "Acme", Location: "Berlin, Germany", Source: "LinkedIn"},
+		{ID: "3", Title: "Go Developer", Company: "Acme", Location: "Munich", Source: "Indeed"},
+	})
+
+	require.Len(t, jobs, 2)
+	assert.Equal(t, "Berlin, Germany", jobs[0].Location, "the location comes from the duplicate")
+	assert.Equal(t, []string{"Greenhouse", "LinkedIn"}, jobs[0].Sources())
+	assert.Equal(t, "Munich", jobs[1].Location, "once known, the location must match")
+}
+
+// TestNormalizeTitle tests that noise and abbreviations don't tell titles apart
+func TestNormalizeTitle(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		{"Sr. Software Engineer", "Senior Software Engineer"},
+		{"Jr Developer", "Junior Developer"},
+		{"Entry Level Developer", "Entry Developer"},
+		{"Backend Dev", "Backend Developer"},
+		{"SWE, Backend", "Backend Software Engineer"},
+		{"C++ Developer (f/m/x)", "C++ Developer"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, normalizeTitle(tt.a), normalizeTitle(tt.b), "%q vs %q", tt.a, tt.b)
+	}
+
+	assert.NotEqual(t, normalizeTitle("C++ Developer"), normalizeTitle("C# Developer"))
+	assert.NotEqual(t, normalizeTitle("Senior Go Developer"), normalizeTitle("Junior Go Developer"))
+}
+
+// TestNormalizeCompany tests that legal forms are ignored
+func TestNormalizeCompany(t *testing.T) {
+	assert.Equal(t, "acme", normalizeCompany("Acme, Inc."))
+	assert.Equal(t, "acme", normalizeCompany("The ACME Corporation"))
+	assert.Equal(t, "acme", normalizeCompany("Acme GmbH"))
+	assert.Equal(t, "company", normalizeCompany("Company"), "a name is never dropped entirely")
+}
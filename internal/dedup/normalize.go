@@ -0,0 +1,115 @@
+package dedup
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// companySuffixes are legal forms dropped from company names, e.g. "Acme, Inc.".
+var companySuffixes = map[string]bool{
+	"inc": true, "incorporated": true, "llc": true, "ltd": true, "limited": true,
+	"corp": true, "corporation": true, "co": true, "company": true, "plc": true,
+	"gmbh": true, "ag": true, "sa": true, "srl": true, "bv": true, "pty": true,
+}
+
+// seniority are the levels of a job. They are kept in titles, since a senior and a
+// junior position at the same company are different jobs.
+var seniority = map[string]bool{
+	"senior": true, "junior": true, "lead": true, "principal": true, "staff": true,
+	"mid": true, "entry": true, "intern": true,
+	"i": true, "ii": true, "iii": true, "iv": true,
+}
+
+// titleNoise are words that don't tell jobs apart, like the "(m/w/d)" of German listings.
+var titleNoise = map[string]bool{
+	"m": true, "w": true, "f": true, "d": true, "x": true, "h": true,
+	"a": true, "an": true, "the": true, "and": true, "of": true, "for": true, "remote": true,
+	"level": true,
+}
+
+// abbreviations are expanded so both spellings of a title match.
+var abbreviations = map[string]string{
+	"dev":  "developer",
+	"devs": "developer",
+	"eng":  "engineer",
+	"engr": "engineer",
+	"mgr":  "manager",
+	"sw":   "software",
+	"swe":  "software engineer",
+	"sde":  "software engineer",
+	"sr":   "senior",
+	"snr":  "senior",
+	"jr":   "junior",
+}
+
+// words splits s into lower case words, dropping punctuation.
+// Symbols that are part of names like "C++" or "C#" are kept.
+func words(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#'
+	})
+}
+
+func normalizeCompany(company string) string {
+	w := words(company)
+	if len(w) > 1 && w[0] == "the" {
+		w = w[1:]
+	}
+	for len(w) > 1 && companySuffixes[w[len(w)-1]] {
+		w = w[:len(w)-1]
+	}
+	return strings.Join(w, " ")
+}
+
+// normalizeLocation keeps the first part of a location, typically the city,
+// so "Berlin" matches "Berlin, Germany".
+func normalizeLocation(location string) string {
+	first, _, _ := strings.Cut(location, ",")
+	return strings.Join(words(first), " ")
+}
+
+// normalizeTitle returns the distinctive words of a title, sorted and without duplicates.
+func normalizeTitle(title string) []string {
+	var tokens []string
+	for _, w := range words(title) {
+		if long, ok := abbreviations[w]; ok {
+			tokens = append(tokens, strings.Fields(long)...)
+			continue
+		}
+		if titleNoise[w] {
+			continue
+		}
+		tokens = append(tokens, w)
+	}
+
+	slices.Sort(tokens)
+	return slices.Compact(tokens)
+}
+
+// titleLevel returns the seniority words of a normalized title, empty when it names no level.
+func titleLevel(title []string) string {
+	var level []string
+	for _, w := range title {
+		if seniority[w] {
+			level = append(level, w)
+		}
+	}
+	return strings.Join(level, " ")
+}
+
+// similarity returns the share of words two normalized titles have in common, from 0 to 1.
+func similarity(a, b []string) float64 {
+	common := 0
+	for _, w := range a {
+		if _, found := slices.BinarySearch(b, w); found {
+			common++
+		}
+	}
+
+	union := len(a) + len(b) - common
+	if union == 0 {
+		return 0
+	}
+	return float64(common) / float64(union)
+}
@@ -0,0 +1,73 @@
+// Package export writes jobs in machine- and human-readable formats.
+package export
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+)
+
+// Exporter writes a list of jobs in a specific format.
+type Exporter interface {
+	Export(w io.Writer, jobs []model.Job) error
+}
+
+// ExporterFunc adapts a function to the Exporter interface.
+type ExporterFunc func(w io.Writer, jobs []model.Job) error
+
+func (f ExporterFunc) Export(w io.Writer, jobs []model.Job) error {
+	return f(w, jobs)
+}
+
+var (
+	formats = map[string]Exporter{}
+
+	// extensions maps file extensions to format names.
+	extensions = map[string]string{}
+)
+
+// Register makes an exporter available under name and for the given file extensions.
+// It is meant to be called from init functions and panics on duplicate names.
+func Register(name string, e Exporter, exts ...string) {
+	if _, dup := formats[name]; dup {
+		panic("export: format registered twice: " + name)
+	}
+
+	formats[name] = e
+	for _, ext := range exts {
+		extensions[ext] = name
+	}
+}
+
+// Get returns the exporter registered under name.
+func Get(name string) (Exporter, error) {
+	e, ok := formats[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown format %q, expected one of %s", name, strings.Join(Names(), ", "))
+	}
+	return e, nil
+}
+
+// ForFile returns the exporter matching the extension of path, e.g. ".csv".
+func ForFile(path string) (Exporter, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	name, ok := extensions[ext]
+	if !ok {
+		return nil, fmt.Errorf("no format for file extension %q", ext)
+	}
+	return formats[name], nil
+}
+
+// Names returns the registered format names, sorted.
+func Names() []string {
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
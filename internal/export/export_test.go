@@ -0,0 +1,147 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+var testJobs = []model.Job{
+	{
+		ID:       "1",
+		Title:    "Go Developer",
+		Company:  "Tech | Corp",
+		Location: "Berlin",
+		Url:      "https://example.com/job/1",
+		Source:   "LinkedIn",
+		Salary:   "€60K–€80K a year",
+		PostedAt: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		ID:          "2",
+		Title:       "<script>alert(1)</script>",
+		Company:     "Evil, Inc.",
+		Source:      "Indeed",
+		Pay:         model.Salary{Min: 50, Max: 60, Currency: "USD", Period: model.PeriodHour},
+		Description: "Line one\nline two",
+	},
+}
+
+func export(t *testing.T, format string) string {
+	e, err := Get(format)
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	assert.NoError(t, e.Export(&buf, testJobs))
+	return buf.String()
+}
+
+// TestExportCSV tests that the CSV export has a header and quotes fields properly
+func TestExportCSV(t *testing.T) {
+	records, err := csv.NewReader(strings.NewReader(export(t, "csv"))).ReadAll()
+
+	assert.NoError(t, err)
+	assert.Len(t, records, 3)
+	assert.Equal(t, csvHeader, records[0])
+	assert.Equal(t, []string{"1", "Go Developer", "Tech | Corp", "Berlin", "€60K–€80K a year", "", "2024-05-01", "LinkedIn", "https://example.com/job/1", ""}, records[1])
+	assert.Equal(t, "Evil, Inc.", records[2][2])
+	assert.Equal(t, "USD 50–60 / hour", records[2][4])
+	assert.Equal(t, "Line one\nline two", records[2][9])
+}
+
+// TestExportJSON tests that the JSON export is an array of jobs
+func TestExportJSON(t *testing.T) {
+	var jobs []model.Job
+	assert.NoError(t, json.Unmarshal([]byte(export(t, "json")), &jobs))
+	assert.Equal(t, testJobs, jobs)
+}
+
+// TestExportJSONEmpty tests that no jobs are exported as an empty array rather than null
+func TestExportJSONEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, exportJSON(&buf, nil))
+	assert.Equal(t, "[]\n", buf.String())
+}
+
+// TestExportJSONL tests that the JSONL export has one job per line
+func TestExportJSONL(t *testing.T) {
+	lines := strings.Split(strings.TrimSpace(export(t, "jsonl")), "\n")
+	assert.Len(t, lines, 2)
+
+	var job model.Job
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &job))
+	assert.Equal(t, "2", job.ID)
+}
+
+// TestExportMarkdown tests that the Markdown table links titles and escapes pipes
+func TestExportMarkdown(t *testing.T) {
+	out := export(t, "markdown")
+
+	assert.Contains(t, out, "| Title | Company | Location | Salary | Source |")
+	assert.Contains(t, out, `| [Go Developer](https://example.com/job/1) | Tech \| Corp | Berlin | €60K–€80K a year | LinkedIn |`)
+}
+
+// TestExportHTML tests that the HTML report escapes job fields
+func TestExportHTML(t *testing.T) {
+	out := export(t, "html")
+
+	assert.Contains(t, out, "<!DOCTYPE html>")
+	assert.Contains(t, out, `<a href="https://example.com/job/1">Go Developer</a>`)
+	assert.Contains(t, out, "2 job(s)")
+	assert.NotContains(t, out, "<script>alert(1)</script>")
+}
+
+// TestExportMergedSources tests that a job found on several sources lists all of them
+func TestExportMergedSources(t *testing.T) {
+	job := model.Job{
+		Title:  "Go Developer",
+		Source: "Indeed",
+		Url:    "https://indeed.com/1",
+		Links: []model.JobLink{
+			{Source: "Indeed", Url: "https://indeed.com/1"},
+			{Source: "LinkedIn", Url: "https://linkedin.com/1"},
+		},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, exportMarkdown(&buf, []model.Job{job}))
+	assert.Contains(t, buf.String(), "| Indeed, LinkedIn |")
+
+	buf.Reset()
+	assert.NoError(t, exportHTML(&buf, []model.Job{job}))
+	assert.Contains(t, buf.String(), `<a href="https://indeed.com/1">Indeed</a>, <a href="https://linkedin.com/1">LinkedIn</a>`)
+}
+
+// TestForFile tests that formats are picked from file extensions
+func TestForFile(t *testing.T) {
+	prefixes := map[string]string{
+		"jobs.csv":       "id,title,",
+		"out/JOBS.JSONL": `{"id":"1",`,
+		"report.html":    "<!DOCTYPE html>",
+		"table.md":       "| Title |",
+	}
+
+	for path, prefix := range prefixes {
+		e, err := ForFile(path)
+		assert.NoError(t, err, path)
+
+		var buf bytes.Buffer
+		assert.NoError(t, e.Export(&buf, testJobs))
+		assert.True(t, strings.HasPrefix(buf.String(), prefix), path)
+	}
+
+	_, err := ForFile("jobs.xlsx")
+	assert.Error(t, err)
+}
+
+// TestGetUnknownFormat tests that unknown formats list the available ones
+func TestGetUnknownFormat(t *testing.T) {
+	_, err := Get("xml")
+	assert.EqualError(t, err, `unknown format "xml", expected one of csv, html, json, jsonl, markdown, text`)
+}
@@ -0,0 +1,133 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+)
+
+func init() {
+	Register("text", ExporterFunc(exportText), ".txt", ".tsv")
+	Register("csv", ExporterFunc(exportCSV), ".csv")
+	Register("json", ExporterFunc(exportJSON), ".json")
+	Register("jsonl", ExporterFunc(exportJSONL), ".jsonl", ".ndjson")
+	Register("markdown", ExporterFunc(exportMarkdown), ".md", ".markdown")
+	Register("html", ExporterFunc(exportHTML), ".html", ".htm")
+}
+
+var csvHeader = []string{"id", "title", "company", "location", "salary", "employment_type", "posted_at", "source", "url", "description"}
+
+// exportText writes one tab-separated line per job.
+func exportText(w io.Writer, jobs []model.Job) error {
+	for _, job := range jobs {
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", job.Title, job.Company, job.Location, sourcesText(job), job.Url); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func exportCSV(w io.Writer, jobs []model.Job) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		record := []string{
+			job.ID,
+			job.Title,
+			job.Company,
+			job.Location,
+			salaryText(job),
+			job.EmploymentType,
+			postedText(job),
+			sourcesText(job),
+			job.Url,
+			job.Description,
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func exportJSON(w io.Writer, jobs []model.Job) error {
+	if jobs == nil {
+		jobs = []model.Job{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(jobs)
+}
+
+func exportJSONL(w io.Writer, jobs []model.Job) error {
+	enc := json.NewEncoder(w)
+	for _, job := range jobs {
+		if err := enc.Encode(job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func exportMarkdown(w io.Writer, jobs []model.Job) error {
+	var b strings.Builder
+
+	b.WriteString("| Title | Company | Location | Salary | Source |\n")
+	b.WriteString("| --- | --- | --- | --- | --- |\n")
+
+	for _, job := range jobs {
+		title := markdownCell(job.Title)
+		if job.Url != "" {
+			title = fmt.Sprintf("[%s](%s)", title, job.Url)
+		}
+
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n",
+			title,
+			markdownCell(job.Company),
+			markdownCell(job.Location),
+			markdownCell(salaryText(job)),
+			markdownCell(sourcesText(job)),
+		)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// markdownCell escapes the characters that would break a table cell.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// salaryText prefers the salary as posted, falling back to the parsed range.
+func salaryText(job model.Job) string {
+	if job.Salary != "" {
+		return job.Salary
+	}
+	return job.Pay.String()
+}
+
+// sourcesText lists every source a job was found on, e.g. "Indeed, LinkedIn".
+func sourcesText(job model.Job) string {
+	return strings.Join(job.Sources(), ", ")
+}
+
+func postedText(job model.Job) string {
+	if job.PostedAt.IsZero() {
+		return ""
+	}
+	return job.PostedAt.Format(time.DateOnly)
+}
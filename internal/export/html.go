@@ -0,0 +1,63 @@
+package export
+
+import (
+	"html/template"
+	"io"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+)
+
+// htmlReport is a self-contained page: styles are inlined and nothing is loaded from the network.
+var htmlReport = template.Must(template.New("report").Funcs(template.FuncMap{
+	"salary": salaryText,
+	"posted": postedText,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Job Results</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2rem; color: #222; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; padding: .5rem; border-bottom: 1px solid #ddd; vertical-align: top; }
+th { background: #f4f4f4; }
+tr:hover td { background: #fafafa; }
+details { max-width: 40rem; }
+.muted { color: #777; font-size: .9em; }
+</style>
+</head>
+<body>
+<h1>Job Results</h1>
+<p class="muted">{{len .Jobs}} job(s), generated {{.Generated.Format "2006-01-02 15:04"}}</p>
+<table>
+<thead>
+<tr><th>Title</th><th>Company</th><th>Location</th><th>Salary</th><th>Posted</th><th>Source</th></tr>
+</thead>
+<tbody>
+{{- range .Jobs}}
+<tr>
+<td>{{if .Url}}<a href="{{.Url}}">{{.Title}}</a>{{else}}{{.Title}}{{end}}
+{{- if .Description}}<details><summary class="muted">Description</summary>{{.Description}}</details>{{end}}</td>
+<td>{{.Company}}</td>
+<td>{{.Location}}</td>
+<td>{{salary .}}</td>
+<td>{{posted .}}</td>
+<td>{{range $i, $link := .Links}}{{if $i}}, {{end}}<a href="{{$link.Url}}">{{$link.Source}}</a>{{else}}{{.Source}}{{end}}</td>
+</tr>
+{{- end}}
+</tbody>
+</table>
+</body>
+</html>
+`))
+
+func exportHTML(w io.Writer, jobs []model.Job) error {
+	return htmlReport.Execute(w, struct {
+		Jobs      []model.Job
+		Generated time.Time
+	}{
+		Jobs:      jobs,
+		Generated: time.Now(),
+	})
+}
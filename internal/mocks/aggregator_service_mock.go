@@ -5,8 +5,11 @@ package mocks
 import (
 	context "context"
 
-	model "github.com/brandoyts/job-aggr/internal/model"
+	aggregator "github.com/brandoyts/job-aggr/internal/service/aggregator"
+
 	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/brandoyts/job-aggr/internal/model"
 )
 
 // AggregatorService is an autogenerated mock type for the AggregatorService type
@@ -14,9 +17,39 @@ type AggregatorService struct {
 	mock.Mock
 }
 
-// FetchJobs provides a mock function with given fields: ctx, query, location
-func (_m *AggregatorService) FetchJobs(ctx context.Context, query string, location string) ([]model.Job, error) {
-	ret := _m.Called(ctx, query, location)
+// Aggregate provides a mock function with given fields: ctx, criteria
+func (_m *AggregatorService) Aggregate(ctx context.Context, criteria model.SearchCriteria) (*aggregator.Result, error) {
+	ret := _m.Called(ctx, criteria)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Aggregate")
+	}
+
+	var r0 *aggregator.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.SearchCriteria) (*aggregator.Result, error)); ok {
+		return rf(ctx, criteria)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.SearchCriteria) *aggregator.Result); ok {
+		r0 = rf(ctx, criteria)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*aggregator.Result)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.SearchCriteria) error); ok {
+		r1 = rf(ctx, criteria)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchJobs provides a mock function with given fields: ctx, criteria
+func (_m *AggregatorService) FetchJobs(ctx context.Context, criteria model.SearchCriteria) ([]model.Job, error) {
+	ret := _m.Called(ctx, criteria)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FetchJobs")
@@ -24,19 +57,19 @@ func (_m *AggregatorService) FetchJobs(ctx context.Context, query string, locati
 
 	var r0 []model.Job
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]model.Job, error)); ok {
-		return rf(ctx, query, location)
+	if rf, ok := ret.Get(0).(func(context.Context, model.SearchCriteria) ([]model.Job, error)); ok {
+		return rf(ctx, criteria)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) []model.Job); ok {
-		r0 = rf(ctx, query, location)
+	if rf, ok := ret.Get(0).(func(context.Context, model.SearchCriteria) []model.Job); ok {
+		r0 = rf(ctx, criteria)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]model.Job)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, query, location)
+	if rf, ok := ret.Get(1).(func(context.Context, model.SearchCriteria) error); ok {
+		r1 = rf(ctx, criteria)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -44,6 +77,26 @@ func (_m *AggregatorService) FetchJobs(ctx context.Context, query string, locati
 	return r0, r1
 }
 
+// Stream provides a mock function with given fields: ctx, criteria
+func (_m *AggregatorService) Stream(ctx context.Context, criteria model.SearchCriteria) <-chan aggregator.Event {
+	ret := _m.Called(ctx, criteria)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stream")
+	}
+
+	var r0 <-chan aggregator.Event
+	if rf, ok := ret.Get(0).(func(context.Context, model.SearchCriteria) <-chan aggregator.Event); ok {
+		r0 = rf(ctx, criteria)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan aggregator.Event)
+		}
+	}
+
+	return r0
+}
+
 // NewAggregatorService creates a new instance of AggregatorService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewAggregatorService(t interface {
@@ -0,0 +1,47 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	model "github.com/brandoyts/job-aggr/internal/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// JobRepository is an autogenerated mock type for the JobRepository type
+type JobRepository struct {
+	mock.Mock
+}
+
+// RecordRun provides a mock function with given fields: ctx, criteria, sources
+func (_m *JobRepository) RecordRun(ctx context.Context, criteria model.SearchCriteria, sources []model.SourceRun) error {
+	ret := _m.Called(ctx, criteria, sources)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordRun")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.SearchCriteria, []model.SourceRun) error); ok {
+		r0 = rf(ctx, criteria, sources)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewJobRepository creates a new instance of JobRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewJobRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *JobRepository {
+	mock := &JobRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -14,9 +14,9 @@ type JobScraper struct {
 	mock.Mock
 }
 
-// Fetch provides a mock function with given fields: ctx, query, location
-func (_m *JobScraper) Fetch(ctx context.Context, query string, location string) ([]model.Job, error) {
-	ret := _m.Called(ctx, query, location)
+// Fetch provides a mock function with given fields: ctx, criteria
+func (_m *JobScraper) Fetch(ctx context.Context, criteria model.SearchCriteria) ([]model.Job, error) {
+	ret := _m.Called(ctx, criteria)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Fetch")
@@ -24,19 +24,19 @@ func (_m *JobScraper) Fetch(ctx context.Context, query string, location string)
 
 	var r0 []model.Job
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]model.Job, error)); ok {
-		return rf(ctx, query, location)
+	if rf, ok := ret.Get(0).(func(context.Context, model.SearchCriteria) ([]model.Job, error)); ok {
+		return rf(ctx, criteria)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) []model.Job); ok {
-		r0 = rf(ctx, query, location)
+	if rf, ok := ret.Get(0).(func(context.Context, model.SearchCriteria) []model.Job); ok {
+		r0 = rf(ctx, criteria)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]model.Job)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, query, location)
+	if rf, ok := ret.Get(1).(func(context.Context, model.SearchCriteria) error); ok {
+		r1 = rf(ctx, criteria)
 	} else {
 		r1 = ret.Error(1)
 	}
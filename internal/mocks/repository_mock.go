@@ -0,0 +1,173 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	model "github.com/brandoyts/job-aggr/internal/model"
+	mock "github.com/stretchr/testify/mock"
+
+	store "github.com/brandoyts/job-aggr/internal/store"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// Close provides a mock function with no fields
+func (_m *Repository) Close() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Close")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LastRun provides a mock function with given fields: ctx, criteria
+func (_m *Repository) LastRun(ctx context.Context, criteria model.SearchCriteria) ([]store.RunJob, error) {
+	ret := _m.Called(ctx, criteria)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LastRun")
+	}
+
+	var r0 []store.RunJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.SearchCriteria) ([]store.RunJob, error)); ok {
+		return rf(ctx, criteria)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.SearchCriteria) []store.RunJob); ok {
+		r0 = rf(ctx, criteria)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]store.RunJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.SearchCriteria) error); ok {
+		r1 = rf(ctx, criteria)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListJobs provides a mock function with given fields: ctx, filter
+func (_m *Repository) ListJobs(ctx context.Context, filter store.Filter) ([]store.StoredJob, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListJobs")
+	}
+
+	var r0 []store.StoredJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.Filter) ([]store.StoredJob, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.Filter) []store.StoredJob); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]store.StoredJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.Filter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordRun provides a mock function with given fields: ctx, criteria, sources
+func (_m *Repository) RecordRun(ctx context.Context, criteria model.SearchCriteria, sources []model.SourceRun) error {
+	ret := _m.Called(ctx, criteria, sources)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordRun")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.SearchCriteria, []model.SourceRun) error); ok {
+		r0 = rf(ctx, criteria, sources)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveSearch provides a mock function with given fields: ctx, name, criteria
+func (_m *Repository) SaveSearch(ctx context.Context, name string, criteria model.SearchCriteria) error {
+	ret := _m.Called(ctx, name, criteria)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveSearch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.SearchCriteria) error); ok {
+		r0 = rf(ctx, name, criteria)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SavedSearch provides a mock function with given fields: ctx, name
+func (_m *Repository) SavedSearch(ctx context.Context, name string) (model.SearchCriteria, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SavedSearch")
+	}
+
+	var r0 model.SearchCriteria
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (model.SearchCriteria, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) model.SearchCriteria); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Get(0).(model.SearchCriteria)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
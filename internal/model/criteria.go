@@ -0,0 +1,109 @@
+package model
+
+import "time"
+
+// WorkplaceType is where the work is done.
+type WorkplaceType string
+
+const (
+	WorkplaceOnSite WorkplaceType = "on-site"
+	WorkplaceHybrid WorkplaceType = "hybrid"
+	WorkplaceRemote WorkplaceType = "remote"
+)
+
+// WorkplaceTypes lists every WorkplaceType.
+var WorkplaceTypes = []WorkplaceType{WorkplaceOnSite, WorkplaceHybrid, WorkplaceRemote}
+
+// JobType is the kind of employment offered.
+type JobType string
+
+const (
+	JobTypeFullTime   JobType = "full-time"
+	JobTypePartTime   JobType = "part-time"
+	JobTypeContract   JobType = "contract"
+	JobTypeTemporary  JobType = "temporary"
+	JobTypeInternship JobType = "internship"
+)
+
+// JobTypes lists every JobType.
+var JobTypes = []JobType{JobTypeFullTime, JobTypePartTime, JobTypeContract, JobTypeTemporary, JobTypeInternship}
+
+// ExperienceLevel is the seniority a job is aimed at.
+type ExperienceLevel string
+
+const (
+	ExperienceInternship ExperienceLevel = "internship"
+	ExperienceEntry      ExperienceLevel = "entry"
+	ExperienceAssociate  ExperienceLevel = "associate"
+	ExperienceMidSenior  ExperienceLevel = "mid-senior"
+	ExperienceDirector   ExperienceLevel = "director"
+	ExperienceExecutive  ExperienceLevel = "executive"
+)
+
+// ExperienceLevels lists every ExperienceLevel, from the most junior.
+var ExperienceLevels = []ExperienceLevel{
+	ExperienceInternship, ExperienceEntry, ExperienceAssociate, ExperienceMidSenior, ExperienceDirector, ExperienceExecutive,
+}
+
+// SortOrder is the order search results are requested in.
+type SortOrder string
+
+const (
+	SortRelevance SortOrder = "relevance"
+	SortDate      SortOrder = "date"
+)
+
+// SortOrders lists every SortOrder.
+var SortOrders = []SortOrder{SortRelevance, SortDate}
+
+// Names of the optional search criteria, used to report which ones a source ignored.
+const (
+	CriterionWorkplace    = "workplace"
+	CriterionRadius       = "radius"
+	CriterionPostedWithin = "posted-within"
+	CriterionJobType      = "job-type"
+	CriterionExperience   = "experience"
+	CriterionMinSalary    = "min-salary"
+	CriterionSort         = "sort"
+)
+
+// SearchCriteria describes a job search. Only Query is required; every other
+// field is optional and left to the source's defaults when zero.
+type SearchCriteria struct {
+	Query        string          `json:"query"`
+	Location     string          `json:"location,omitempty"`
+	Workplace    WorkplaceType   `json:"workplace,omitempty"`
+	RadiusMiles  int             `json:"radius_miles,omitempty"`
+	PostedWithin time.Duration   `json:"posted_within,omitempty"`
+	JobType      JobType         `json:"job_type,omitempty"`
+	Experience   ExperienceLevel `json:"experience,omitempty"`
+	MinSalary    int             `json:"min_salary,omitempty"` // yearly, in the currency of the searched location
+	Sort         SortOrder       `json:"sort,omitempty"`
+}
+
+// Criteria returns the names of the optional criteria that are set.
+func (c SearchCriteria) Criteria() []string {
+	var set []string
+	if c.Workplace != "" {
+		set = append(set, CriterionWorkplace)
+	}
+	if c.RadiusMiles > 0 {
+		set = append(set, CriterionRadius)
+	}
+	if c.PostedWithin > 0 {
+		set = append(set, CriterionPostedWithin)
+	}
+	if c.JobType != "" {
+		set = append(set, CriterionJobType)
+	}
+	if c.Experience != "" {
+		set = append(set, CriterionExperience)
+	}
+	if c.MinSalary > 0 {
+		set = append(set, CriterionMinSalary)
+	}
+	if c.Sort != "" {
+		set = append(set, CriterionSort)
+	}
+	return set
+}
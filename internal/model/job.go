@@ -1,12 +1,44 @@
 package model
 
+import "time"
+
 type Job struct {
-	ID          string
-	Title       string
-	Company     string
-	Location    string
-	Url         string
-	Source      string
-	Salary      string
-	Description string
+	ID             string        `json:"id"`
+	Title          string        `json:"title"`
+	Company        string        `json:"company"`
+	Location       string        `json:"location"`
+	Url            string        `json:"url"`
+	Source         string        `json:"source"`
+	Salary         string        `json:"salary,omitempty"`
+	Description    string        `json:"description,omitempty"`
+	EmploymentType string        `json:"employment_type,omitempty"`
+	Department     string        `json:"department,omitempty"`
+	Workplace      WorkplaceType `json:"workplace,omitempty"` // empty when the source doesn't say
+	ApplyUrl       string        `json:"apply_url,omitempty"` // when applying happens elsewhere than Url
+	Regions        string        `json:"regions,omitempty"`   // where remote applicants must be based, e.g. "USA only" or "UTC-3 to UTC+3"
+	Tags           []string      `json:"tags,omitempty"`      // skills and categories given by the source
+	PostedAt       time.Time     `json:"posted_at,omitzero"`
+	ExpiresAt      time.Time     `json:"expires_at,omitzero"` // when the posting closes, if announced
+	Pay            Salary        `json:"pay,omitzero"`        // structured form of Salary, when it could be parsed
+	Links          []JobLink     `json:"links,omitempty"`     // every listing of a job merged from several sources
+}
+
+// JobLink is a listing of a job on one of the sources.
+type JobLink struct {
+	Source string `json:"source"`
+	ID     string `json:"id,omitempty"`
+	Url    string `json:"url"`
+}
+
+// Sources returns the names of the sources the job was found on.
+func (j Job) Sources() []string {
+	if len(j.Links) == 0 {
+		return []string{j.Source}
+	}
+
+	sources := make([]string, len(j.Links))
+	for i, link := range j.Links {
+		sources[i] = link.Source
+	}
+	return sources
 }
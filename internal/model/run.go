@@ -0,0 +1,8 @@
+package model
+
+// SourceRun is what a single source found in a run of a search.
+type SourceRun struct {
+	Source string // name the source is reported by, e.g. "Indeed"; the jobs may name another, e.g. a feed's
+	Jobs   []Job
+	Failed bool // the source returned an error: Jobs are those it read before, if any
+}
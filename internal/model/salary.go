@@ -0,0 +1,110 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SalaryPeriod is the unit of time a salary amount is paid for.
+type SalaryPeriod string
+
+const (
+	PeriodHour  SalaryPeriod = "hour"
+	PeriodDay   SalaryPeriod = "day"
+	PeriodWeek  SalaryPeriod = "week"
+	PeriodMonth SalaryPeriod = "month"
+	PeriodYear  SalaryPeriod = "year"
+)
+
+// periodsPerYear converts an amount paid per period into a yearly amount,
+// assuming full-time work (40 hours a week, 52 weeks a year).
+var periodsPerYear = map[SalaryPeriod]float64{
+	PeriodHour:  2080,
+	PeriodDay:   260,
+	PeriodWeek:  52,
+	PeriodMonth: 12,
+	PeriodYear:  1,
+}
+
+// Salary is a structured pay range. Either bound may be zero when the posting
+// only gives one, e.g. "Up to $90,000".
+type Salary struct {
+	Min       float64      `json:"min,omitempty"`
+	Max       float64      `json:"max,omitempty"`
+	Currency  string       `json:"currency,omitempty"` // ISO 4217 code, e.g. "USD"
+	Period    SalaryPeriod `json:"period,omitempty"`
+	Estimated bool         `json:"estimated,omitempty"` // the amount was estimated by the job board rather than posted by the employer
+}
+
+// IsZero reports whether no salary is known.
+func (s Salary) IsZero() bool {
+	return s.Min == 0 && s.Max == 0
+}
+
+// Annual returns the range converted to a yearly amount so salaries paid
+// per hour, day, week or month can be compared.
+func (s Salary) Annual() (min float64, max float64) {
+	factor, ok := periodsPerYear[s.Period]
+	if !ok {
+		factor = 1
+	}
+	return s.Min * factor, s.Max * factor
+}
+
+// AnnualMidpoint returns a single yearly figure for sorting: the middle of the
+// range, or whichever bound is known.
+func (s Salary) AnnualMidpoint() float64 {
+	min, max := s.Annual()
+	switch {
+	case min == 0:
+		return max
+	case max == 0:
+		return min
+	default:
+		return (min + max) / 2
+	}
+}
+
+// String formats the salary, e.g. "USD 120,000–150,000 / year (estimated)".
+func (s Salary) String() string {
+	if s.IsZero() {
+		return ""
+	}
+
+	var amount string
+	switch {
+	case s.Min == 0:
+		amount = "up to " + formatAmount(s.Max)
+	case s.Max == 0:
+		amount = "from " + formatAmount(s.Min)
+	case s.Min == s.Max:
+		amount = formatAmount(s.Min)
+	default:
+		amount = formatAmount(s.Min) + "–" + formatAmount(s.Max)
+	}
+
+	out := strings.TrimSpace(s.Currency + " " + amount)
+	if s.Period != "" {
+		out += " / " + string(s.Period)
+	}
+	if s.Estimated {
+		out += " (estimated)"
+	}
+	return out
+}
+
+func formatAmount(v float64) string {
+	if v != float64(int64(v)) {
+		return fmt.Sprintf("%.2f", v)
+	}
+
+	digits := fmt.Sprintf("%d", int64(v))
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(d)
+	}
+	return b.String()
+}
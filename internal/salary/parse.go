@@ -0,0 +1,198 @@
+// Package salary parses the free-form salary text found in job postings.
+package salary
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+)
+
+var (
+	amountPattern    = regexp.MustCompile(`(\d+(?:[.,]\d+)*)\s*([kKmM])?\b`)
+	separatorPattern = regexp.MustCompile(`[.,]`)
+
+	// rangePattern matches what joins the two amounts of a range, e.g. " - $" or "/yr to CA$".
+	rangePattern = regexp.MustCompile(`^\s*\S{0,4}\s*(?:-|–|—|to)\s*\S{0,4}\s*$`)
+
+	// yearPattern matches a bare number that is more likely a year, e.g. "est. 2020", than pay.
+	yearPattern = regexp.MustCompile(`^\d{4}$`)
+
+	// currencySymbols is checked in order, so longer prefixes come first.
+	currencySymbols = []struct {
+		symbol string
+		code   string
+	}{
+		{"US$", "USD"},
+		{"CA$", "CAD"},
+		{"C$", "CAD"},
+		{"A$", "AUD"},
+		{"AU$", "AUD"},
+		{"NZ$", "NZD"},
+		{"S$", "SGD"},
+		{"$", "USD"},
+		{"€", "EUR"},
+		{"£", "GBP"},
+		{"₹", "INR"},
+		{"¥", "JPY"},
+		{"₱", "PHP"},
+	}
+
+	// currencyCodes only match whole words, for "EUR" not to be found in "Europe".
+	currencyCodes = regexp.MustCompile(`\b(USD|EUR|GBP|CAD|AUD|NZD|SGD|INR|JPY|PHP|CHF|SEK|NOK|DKK|PLN)\b`)
+
+	// Words stating that an amount is the upper or lower bound, e.g. "up to $90,000".
+	maxPattern = regexp.MustCompile(`\b(up to|max|maximum)\b`)
+	minPattern = regexp.MustCompile(`\b(from|starting|min|minimum)\b`)
+
+	periodWords = []struct {
+		pattern *regexp.Regexp
+		period  model.SalaryPeriod
+	}{
+		{regexp.MustCompile(`\b(an? hour|per hour|hourly|/\s*(hour|hr|h))\b`), model.PeriodHour},
+		{regexp.MustCompile(`\b(a day|per day|daily|/\s*(day|d))\b`), model.PeriodDay},
+		{regexp.MustCompile(`\b(a week|per week|weekly|/\s*(week|wk))\b`), model.PeriodWeek},
+		{regexp.MustCompile(`\b(a month|per month|monthly|/\s*(month|mo))\b`), model.PeriodMonth},
+		{regexp.MustCompile(`\b(a year|per year|yearly|annually|annual|per annum|p\.a|/\s*(year|yr|y))\b`), model.PeriodYear},
+	}
+)
+
+// Parse extracts a salary range from text such as "$120K–$150K a year",
+// "€45/hour" or "Up to $90,000". It reports false when no amount is found.
+//
+// Only two amounts joined by "-", "–" or "to" make a range, and a suffix on the
+// upper bound applies to both, e.g. "$80-100k". Percentages, e.g. of a bonus, are
+// skipped, and so are bare four-digit numbers when the text names neither a
+// currency nor a period, since those are usually years.
+//
+// When the text doesn't state a period, amounts below 1,000 are assumed to be
+// hourly and larger ones yearly.
+func Parse(text string) (model.Salary, bool) {
+	lower := strings.ToLower(text)
+	cur := currency(text)
+	per := period(lower)
+
+	type amount struct {
+		value          float64
+		digits, suffix string
+		start, end     int
+	}
+
+	var amounts []amount
+	for _, m := range amountPattern.FindAllStringSubmatchIndex(text, -1) {
+		digits, suffix := text[m[2]:m[3]], ""
+		if m[4] >= 0 {
+			suffix = text[m[4]:m[5]]
+		}
+
+		if strings.HasPrefix(strings.TrimSpace(text[m[1]:]), "%") {
+			continue
+		}
+		if cur == "" && per == "" && suffix == "" && yearPattern.MatchString(digits) {
+			continue
+		}
+
+		v, ok := parseAmount(digits, suffix)
+		if ok {
+			amounts = append(amounts, amount{v, digits, suffix, m[0], m[1]})
+		}
+	}
+
+	if len(amounts) == 0 {
+		return model.Salary{}, false
+	}
+
+	s := model.Salary{
+		Currency:  cur,
+		Period:    per,
+		Estimated: strings.Contains(lower, "estimate") || strings.Contains(lower, "est."),
+	}
+
+	first := amounts[0]
+	isRange := len(amounts) >= 2 && rangePattern.MatchString(text[first.end:amounts[1].start])
+
+	switch {
+	case isRange:
+		second := amounts[1]
+		s.Min, s.Max = first.value, second.value
+		if first.suffix == "" && second.suffix != "" {
+			if shared, _ := parseAmount(first.digits, second.suffix); shared <= s.Max {
+				s.Min = shared
+			}
+		}
+		if s.Min > s.Max {
+			s.Min, s.Max = s.Max, s.Min
+		}
+	case maxPattern.MatchString(lower):
+		s.Max = first.value
+	case minPattern.MatchString(lower) || strings.HasPrefix(text[first.end:], "+"):
+		s.Min = first.value
+	default:
+		s.Min, s.Max = first.value, first.value
+	}
+
+	if s.Period == "" {
+		if s.Min < 1000 && s.Max < 1000 {
+			s.Period = model.PeriodHour
+		} else {
+			s.Period = model.PeriodYear
+		}
+	}
+
+	return s, true
+}
+
+// parseAmount converts "120,000", "45.50" or "120" with suffix "K" into a number.
+func parseAmount(digits string, suffix string) (float64, bool) {
+	// A comma or dot followed by exactly three digits separates thousands.
+	parts := separatorPattern.Split(digits, -1)
+	var b strings.Builder
+	b.WriteString(parts[0])
+	for i, p := range parts[1:] {
+		if len(p) == 3 {
+			b.WriteString(p)
+			continue
+		}
+		// Anything else is a decimal part, which can only come last.
+		if i != len(parts)-2 {
+			return 0, false
+		}
+		b.WriteString("." + p)
+	}
+
+	v, err := strconv.ParseFloat(b.String(), 64)
+	if err != nil {
+		return 0, false
+	}
+
+	switch strings.ToLower(suffix) {
+	case "k":
+		v *= 1_000
+	case "m":
+		v *= 1_000_000
+	}
+
+	return v, true
+}
+
+// currency returns the ISO code of the currency of text: its symbol, or a code given as a word.
+func currency(text string) string {
+	upper := strings.ToUpper(text)
+	for _, c := range currencySymbols {
+		if strings.Contains(upper, c.symbol) {
+			return c.code
+		}
+	}
+
+	return currencyCodes.FindString(upper)
+}
+
+func period(lower string) model.SalaryPeriod {
+	for _, p := range periodWords {
+		if p.pattern.MatchString(lower) {
+			return p.period
+		}
+	}
+	return ""
+}
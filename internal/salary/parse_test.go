@@ -0,0 +1,70 @@
+package salary
+
+import (
+	"testing"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParse tests the salary formats commonly found on job boards
+func TestParse(t *testing.T) {
+	tests := []struct {
+		text string
+		want model.Salary
+	}{
+		{"$120K–$150K a year", model.Salary{Min: 120000, Max: 150000, Currency: "USD", Period: model.PeriodYear}},
+		{"$120,000 - $150,000 a year", model.Salary{Min: 120000, Max: 150000, Currency: "USD", Period: model.PeriodYear}},
+		{"€45/hour", model.Salary{Min: 45, Max: 45, Currency: "EUR", Period: model.PeriodHour}},
+		{"Up to $90,000", model.Salary{Max: 90000, Currency: "USD", Period: model.PeriodYear}},
+		{"From £3,500 a month", model.Salary{Min: 3500, Currency: "GBP", Period: model.PeriodMonth}},
+		{"$25.50 - $30 an hour", model.Salary{Min: 25.5, Max: 30, Currency: "USD", Period: model.PeriodHour}},
+		{"Estimated $95.4K - $121K a year", model.Salary{Min: 95400, Max: 121000, Currency: "USD", Period: model.PeriodYear, Estimated: true}},
+		{"CA$80,000/yr - CA$100,000/yr", model.Salary{Min: 80000, Max: 100000, Currency: "CAD", Period: model.PeriodYear}},
+		{"PHP 60,000 - 80,000 monthly", model.Salary{Min: 60000, Max: 80000, Currency: "PHP", Period: model.PeriodMonth}},
+		{"$400 a day", model.Salary{Min: 400, Max: 400, Currency: "USD", Period: model.PeriodDay}},
+		{"$100K+", model.Salary{Min: 100000, Currency: "USD", Period: model.PeriodYear}},
+		{"$50 to $60 per hour", model.Salary{Min: 50, Max: 60, Currency: "USD", Period: model.PeriodHour}},
+		{"$120,000 a year + 10% bonus", model.Salary{Min: 120000, Max: 120000, Currency: "USD", Period: model.PeriodYear}},
+		{"Up to $90,000 plus 15% bonus", model.Salary{Max: 90000, Currency: "USD", Period: model.PeriodYear}},
+		{"€4500 a month", model.Salary{Min: 4500, Max: 4500, Currency: "EUR", Period: model.PeriodMonth}},
+		{"4000 per month", model.Salary{Min: 4000, Max: 4000, Period: model.PeriodMonth}},
+		{"Remote (Europe) $120k - $150k", model.Salary{Min: 120000, Max: 150000, Currency: "USD", Period: model.PeriodYear}},
+		{"EUR 60,000 - 70,000", model.Salary{Min: 60000, Max: 70000, Currency: "EUR", Period: model.PeriodYear}},
+		{"$80-100k", model.Salary{Min: 80000, Max: 100000, Currency: "USD", Period: model.PeriodYear}},
+		{"€1.2-1.5M", model.Salary{Min: 1200000, Max: 1500000, Currency: "EUR", Period: model.PeriodYear}},
+		{"$800 - 1.2k a month", model.Salary{Min: 800, Max: 1200, Currency: "USD", Period: model.PeriodMonth}},
+		{"$150,000 base, admin role", model.Salary{Min: 150000, Max: 150000, Currency: "USD", Period: model.PeriodYear}},
+		{"$90,000 for a maxillofacial practice", model.Salary{Min: 90000, Max: 90000, Currency: "USD", Period: model.PeriodYear}},
+		{"Min. $60,000", model.Salary{Min: 60000, Currency: "USD", Period: model.PeriodYear}},
+	}
+
+	for _, tt := range tests {
+		got, ok := Parse(tt.text)
+		assert.True(t, ok, tt.text)
+		assert.Equal(t, tt.want, got, tt.text)
+	}
+}
+
+// TestParseWithoutAmount tests that text without any amount is rejected
+func TestParseWithoutAmount(t *testing.T) {
+	for _, text := range []string{"", "Competitive", "Depends on experience", "Competitive, est. 2020", "10% bonus"} {
+		_, ok := Parse(text)
+		assert.False(t, ok, text)
+	}
+}
+
+// TestAnnual tests that salaries are normalized to yearly amounts
+func TestAnnual(t *testing.T) {
+	hourly, _ := Parse("$50 an hour")
+	min, max := hourly.Annual()
+	assert.Equal(t, 104000.0, min)
+	assert.Equal(t, 104000.0, max)
+
+	monthly, _ := Parse("€4,000 - €5,000 a month")
+	assert.Equal(t, 54000.0, monthly.AnnualMidpoint())
+
+	upTo, _ := Parse("Up to $90,000")
+	assert.Equal(t, 90000.0, upTo.AnnualMidpoint())
+	assert.Equal(t, "USD up to 90,000 / year", upTo.String())
+}
@@ -3,7 +3,9 @@ package aggregator
 import (
 	"context"
 	"sync"
+	"time"
 
+	"github.com/brandoyts/job-aggr/internal/dedup"
 	"github.com/brandoyts/job-aggr/internal/model"
 )
 
@@ -11,50 +13,132 @@ import (
 //
 //go:generate mockery --name=AggregatorService --output=../../mocks --outpkg=mocks --filename=aggregator_service_mock.go
 type AggregatorService interface {
-	FetchJobs(ctx context.Context, query string, location string) ([]model.Job, error)
+	FetchJobs(ctx context.Context, criteria model.SearchCriteria) ([]model.Job, error)
+	Aggregate(ctx context.Context, criteria model.SearchCriteria) (*Result, error)
+	Stream(ctx context.Context, criteria model.SearchCriteria) <-chan Event
 }
 
 // JobScraper defines the interface for fetching jobs from a specific source.
 //
 //go:generate mockery --name=JobScraper --output=../../mocks --outpkg=mocks --filename=job_scraper_mock.go
 type JobScraper interface {
-	Fetch(ctx context.Context, query string, location string) ([]model.Job, error)
+	Fetch(ctx context.Context, criteria model.SearchCriteria) ([]model.Job, error)
+}
+
+// CriteriaSupport is implemented by scrapers that can tell which search criteria
+// they are unable to honor. Those are listed in the source's report.
+type CriteriaSupport interface {
+	Unsupported(criteria model.SearchCriteria) []string
 }
 
 // aggregatorService implements AggregatorService by combining results from multiple scrapers.
 type aggregatorService struct {
-	scrapers []JobScraper
+	scrapers    []JobScraper
+	policy      Policy
+	enrichSlots int
+	repo        JobRepository
+	dedup       bool
 }
 
 // NewAggregatorService creates a new aggregator service with the given scrapers.
-// Scrapers are called in order, and any error from a scraper will stop the aggregation.
+// Scrapers run concurrently, and their failures are handled by the failure policy:
+// FailFast, which stops the aggregation at the first error, unless another one is
+// selected through NewAggregatorServiceWithOptions and WithPolicy.
 func NewAggregatorService(scrapers ...JobScraper) AggregatorService {
-	return &aggregatorService{scrapers: scrapers}
+	return NewAggregatorServiceWithOptions(scrapers)
+}
+
+// NewAggregatorServiceWithOptions creates a new aggregator service with the given scrapers
+// and options. Without options it behaves like NewAggregatorService.
+func NewAggregatorServiceWithOptions(scrapers []JobScraper, opts ...Option) AggregatorService {
+	a := &aggregatorService{
+		scrapers: scrapers,
+		policy:   FailFast,
+	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a
 }
 
 // FetchJobs fetches jobs from all registered scrapers and aggregates the results.
-// If any scraper returns an error, the aggregation stops and the error is returned.
-// Results are aggregated in the order that scrapers are called.
-func (a *aggregatorService) FetchJobs(ctx context.Context, query string, location string) ([]model.Job, error) {
+// With the default FailFast policy, the aggregation stops on the first scraper error
+// and that error is returned. Results are aggregated in the order that scrapers finish.
+func (a *aggregatorService) FetchJobs(ctx context.Context, criteria model.SearchCriteria) ([]model.Job, error) {
+	result, err := a.Aggregate(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Jobs, nil
+}
+
+// Aggregate fetches jobs from all registered scrapers and reports the outcome of each one.
+// Under FailFast the first scraper error is returned unchanged. Under the other policies
+// every scraper is awaited, and a *SourceError is returned when fewer scrapers than
+// required succeeded.
+func (a *aggregatorService) Aggregate(ctx context.Context, criteria model.SearchCriteria) (*Result, error) {
+	return a.collect(ctx, criteria, nil)
+}
+
+// Stream fetches jobs from all registered scrapers and emits one batch event per scraper
+// as soon as it finishes, followed by a single Done event carrying what Aggregate would
+// have returned. The channel is closed after the Done event. It is buffered for every
+// event, so callers may stop reading early without leaking goroutines.
+func (a *aggregatorService) Stream(ctx context.Context, criteria model.SearchCriteria) <-chan Event {
+	events := make(chan Event, len(a.scrapers)+1)
+
+	go func() {
+		defer close(events)
+
+		result, err := a.collect(ctx, criteria, func(report SourceReport, jobs []model.Job) {
+			events <- Event{Report: report, Jobs: jobs}
+		})
+
+		events <- Event{Done: true, Result: result, Err: err}
+	}()
+
+	return events
+}
+
+// collect runs every scraper concurrently and applies the failure policy.
+// If onBatch is not nil, it is called once for every scraper as it finishes.
+func (a *aggregatorService) collect(ctx context.Context, criteria model.SearchCriteria, onBatch func(SourceReport, []model.Job)) (*Result, error) {
 	type result struct {
-		jobs []model.Job
-		err  error
+		index int
+		jobs  []model.Job
+		err   error
+		took  time.Duration
 	}
 
 	resultCh := make(chan result, len(a.scrapers))
 	var wg sync.WaitGroup
 
+	// Shared by every scraper so enrichment concurrency is bounded globally.
+	var enrichSem chan struct{}
+	if a.enrichSlots > 0 {
+		enrichSem = make(chan struct{}, a.enrichSlots)
+	}
+
 	wg.Add(len(a.scrapers))
 
-	for _, scraper := range a.scrapers {
+	for i, scraper := range a.scrapers {
 		s := scraper
 		go func() {
 			defer wg.Done()
-			jobs, err := s.Fetch(ctx, query, location)
+			start := time.Now()
+			jobs, err := s.Fetch(ctx, criteria)
+
+			if e, ok := s.(Enricher); ok && err == nil && enrichSem != nil {
+				enrich(ctx, e, enrichSem, jobs)
+			}
+			normalizePay(jobs)
 
 			// Always send result, even if context is canceled
 			select {
-			case resultCh <- result{jobs: jobs, err: err}:
+			case resultCh <- result{index: i, jobs: jobs, err: err, took: time.Since(start)}:
 			default: // channel full or closed, skip
 			}
 		}()
@@ -65,12 +149,37 @@ func (a *aggregatorService) FetchJobs(ctx context.Context, query string, locatio
 		close(resultCh)
 	}()
 
-	var allJobs []model.Job
+	out := &Result{Reports: make([]SourceReport, len(a.scrapers))}
+	runs := make([]model.SourceRun, len(a.scrapers))
+	for i, s := range a.scrapers {
+		out.Reports[i] = SourceReport{Source: sourceName(s, i)}
+		runs[i].Source = out.Reports[i].Source
+		if cs, ok := s.(CriteriaSupport); ok {
+			out.Reports[i].Unsupported = cs.Unsupported(criteria)
+		}
+	}
+
 	for res := range resultCh {
-		if res.err != nil {
+		report := &out.Reports[res.index]
+		report.Err = res.err
+		report.Duration = res.took
+
+		// Jobs returned along with an error were read before the source failed, e.g.
+		// on the first pages of its results. They are kept, and the source is still
+		// reported as failed for the policy to decide.
+		jobs := applyMinSalary(report, criteria, res.jobs)
+		report.Jobs = len(jobs)
+		out.Jobs = append(out.Jobs, jobs...)
+		runs[res.index].Jobs = jobs
+		runs[res.index].Failed = res.err != nil
+
+		if onBatch != nil {
+			onBatch(*report, jobs)
+		}
+
+		if res.err != nil && a.policy.failFast {
 			return nil, res.err
 		}
-		allJobs = append(allJobs, res.jobs...)
 	}
 
 	// If context was canceled before any result, return ctx.Err()
@@ -78,5 +187,15 @@ func (a *aggregatorService) FetchJobs(ctx context.Context, query string, locatio
 		return nil, ctx.Err()
 	}
 
-	return allJobs, nil
+	a.persist(ctx, criteria, runs, out)
+
+	if a.dedup {
+		out.Jobs = dedup.Merge(out.Jobs)
+	}
+
+	if out.Succeeded() < a.policy.minSources {
+		return out, &SourceError{Result: out}
+	}
+
+	return out, nil
 }
@@ -1,4 +1,4 @@
-package aggregator
+package aggregator_test
 
 import (
 	"context"
@@ -7,6 +7,7 @@ import (
 
 	"github.com/brandoyts/job-aggr/internal/mocks"
 	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/aggregator"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -16,10 +17,10 @@ func TestNewAggregatorService(t *testing.T) {
 	scraper1 := mocks.NewJobScraper(t)
 	scraper2 := mocks.NewJobScraper(t)
 
-	service := NewAggregatorService(scraper1, scraper2)
+	service := aggregator.NewAggregatorService(scraper1, scraper2)
 
 	assert.NotNil(t, service)
-	assert.Implements(t, (*AggregatorService)(nil), service)
+	assert.Implements(t, (*aggregator.AggregatorService)(nil), service)
 }
 
 // TestFetchJobsSingleScraper tests fetching jobs from a single scraper
@@ -44,17 +45,17 @@ func TestFetchJobsSingleScraper(t *testing.T) {
 		},
 	}
 
-	scraper.On("Fetch", mock.Anything, "golang", "New York").Return(jobs, nil)
+	scraper.On("Fetch", mock.Anything, model.SearchCriteria{Query: "golang", Location: "New York"}).Return(jobs, nil)
 
-	service := NewAggregatorService(scraper)
+	service := aggregator.NewAggregatorService(scraper)
 	ctx := context.Background()
 
-	result, err := service.FetchJobs(ctx, "golang", "New York")
+	result, err := service.FetchJobs(ctx, model.SearchCriteria{Query: "golang", Location: "New York"})
 
 	assert.NoError(t, err)
 	assert.Equal(t, len(jobs), len(result))
 	assert.Equal(t, jobs, result)
-	scraper.AssertCalled(t, "Fetch", mock.Anything, "golang", "New York")
+	scraper.AssertCalled(t, "Fetch", mock.Anything, model.SearchCriteria{Query: "golang", Location: "New York"})
 }
 
 // TestFetchJobsMultipleScrapers tests fetching and aggregating jobs from multiple scrapers
@@ -92,17 +93,17 @@ func TestFetchJobsMultipleScrapers(t *testing.T) {
 		},
 	}
 
-	scraper1.On("Fetch", mock.Anything, "golang", "New York").Return(jobsFromScraper1, nil)
-	scraper2.On("Fetch", mock.Anything, "golang", "New York").Return(jobsFromScraper2, nil)
+	scraper1.On("Fetch", mock.Anything, model.SearchCriteria{Query: "golang", Location: "New York"}).Return(jobsFromScraper1, nil)
+	scraper2.On("Fetch", mock.Anything, model.SearchCriteria{Query: "golang", Location: "New York"}).Return(jobsFromScraper2, nil)
 
-	service := NewAggregatorService(scraper1, scraper2)
+	service := aggregator.NewAggregatorService(scraper1, scraper2)
 	ctx := context.Background()
 
-	_, err := service.FetchJobs(ctx, "golang", "New York")
+	_, err := service.FetchJobs(ctx, model.SearchCriteria{Query: "golang", Location: "New York"})
 
 	assert.NoError(t, err)
-	scraper1.AssertCalled(t, "Fetch", mock.Anything, "golang", "New York")
-	scraper2.AssertCalled(t, "Fetch", mock.Anything, "golang", "New York")
+	scraper1.AssertCalled(t, "Fetch", mock.Anything, model.SearchCriteria{Query: "golang", Location: "New York"})
+	scraper2.AssertCalled(t, "Fetch", mock.Anything, model.SearchCriteria{Query: "golang", Location: "New York"})
 }
 
 // TestFetchJobsEmptyResults tests fetching when all scrapers return empty results
@@ -110,13 +111,13 @@ func TestFetchJobsEmptyResults(t *testing.T) {
 	scraper1 := mocks.NewJobScraper(t)
 	scraper2 := mocks.NewJobScraper(t)
 
-	scraper1.On("Fetch", mock.Anything, "obscurequery", "location").Return([]model.Job{}, nil)
-	scraper2.On("Fetch", mock.Anything, "obscurequery", "location").Return([]model.Job{}, nil)
+	scraper1.On("Fetch", mock.Anything, model.SearchCriteria{Query: "obscurequery", Location: "location"}).Return([]model.Job{}, nil)
+	scraper2.On("Fetch", mock.Anything, model.SearchCriteria{Query: "obscurequery", Location: "location"}).Return([]model.Job{}, nil)
 
-	service := NewAggregatorService(scraper1, scraper2)
+	service := aggregator.NewAggregatorService(scraper1, scraper2)
 	ctx := context.Background()
 
-	result, err := service.FetchJobs(ctx, "obscurequery", "location")
+	result, err := service.FetchJobs(ctx, model.SearchCriteria{Query: "obscurequery", Location: "location"})
 
 	assert.NoError(t, err)
 	assert.Equal(t, 0, len(result))
@@ -131,7 +132,7 @@ func TestFetchJobsScraperError(t *testing.T) {
 	blockScraper1 := make(chan struct{})
 
 	// scraper1 is blocked until after aggregator returns
-	scraper1.On("Fetch", mock.Anything, "golang", "location").
+	scraper1.On("Fetch", mock.Anything, model.SearchCriteria{Query: "golang", Location: "location"}).
 		Run(func(args mock.Arguments) {
 			<-blockScraper1
 		}).
@@ -140,13 +141,13 @@ func TestFetchJobsScraperError(t *testing.T) {
 		}, nil)
 
 	// scraper2 returns error immediately
-	scraper2.On("Fetch", mock.Anything, "golang", "location").
+	scraper2.On("Fetch", mock.Anything, model.SearchCriteria{Query: "golang", Location: "location"}).
 		Return(nil, errors.New("network error"))
 
-	service := NewAggregatorService(scraper1, scraper2)
+	service := aggregator.NewAggregatorService(scraper1, scraper2)
 	ctx := context.Background()
 
-	result, err := service.FetchJobs(ctx, "golang", "location")
+	result, err := service.FetchJobs(ctx, model.SearchCriteria{Query: "golang", Location: "location"})
 
 	// Release scraper1 after aggregator returns (cleanup)
 	close(blockScraper1)
@@ -155,16 +156,16 @@ func TestFetchJobsScraperError(t *testing.T) {
 	assert.Nil(t, result)
 	assert.Equal(t, "network error", err.Error())
 
-	scraper1.AssertCalled(t, "Fetch", mock.Anything, "golang", "location")
-	scraper2.AssertCalled(t, "Fetch", mock.Anything, "golang", "location")
+	scraper1.AssertCalled(t, "Fetch", mock.Anything, model.SearchCriteria{Query: "golang", Location: "location"})
+	scraper2.AssertCalled(t, "Fetch", mock.Anything, model.SearchCriteria{Query: "golang", Location: "location"})
 }
 
 // TestFetchJobsNoScrapers tests fetching with no scrapers registered
 func TestFetchJobsNoScrapers(t *testing.T) {
-	service := NewAggregatorService()
+	service := aggregator.NewAggregatorService()
 	ctx := context.Background()
 
-	result, err := service.FetchJobs(ctx, "golang", "location")
+	result, err := service.FetchJobs(ctx, model.SearchCriteria{Query: "golang", Location: "location"})
 
 	assert.NoError(t, err)
 	assert.Nil(t, result)
@@ -178,22 +179,22 @@ func TestFetchJobsContextCancellation(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	// Cancel context as soon as scraper.Fetch is called
-	scraper.On("Fetch", mock.Anything, "golang", "location").
+	scraper.On("Fetch", mock.Anything, model.SearchCriteria{Query: "golang", Location: "location"}).
 		Run(func(args mock.Arguments) {
 			cancel() // cancel context immediately
 		}).
 		Return(nil, context.Canceled)
 
-	service := NewAggregatorService(scraper)
+	service := aggregator.NewAggregatorService(scraper)
 
-	result, err := service.FetchJobs(ctx, "golang", "location")
+	result, err := service.FetchJobs(ctx, model.SearchCriteria{Query: "golang", Location: "location"})
 
 	// Use assert.ErrorIs to safely compare context errors
 	assert.Error(t, err)
 	assert.Nil(t, result)
 	assert.ErrorIs(t, err, context.Canceled)
 
-	scraper.AssertCalled(t, "Fetch", mock.Anything, "golang", "location")
+	scraper.AssertCalled(t, "Fetch", mock.Anything, model.SearchCriteria{Query: "golang", Location: "location"})
 }
 
 // TestFetchJobsWithJobsContainingAllFields tests that all job fields are preserved
@@ -212,12 +213,12 @@ func TestFetchJobsWithJobsContainingAllFields(t *testing.T) {
 		},
 	}
 
-	scraper.On("Fetch", mock.Anything, "senior golang", "location").Return(jobs, nil)
+	scraper.On("Fetch", mock.Anything, model.SearchCriteria{Query: "senior golang", Location: "location"}).Return(jobs, nil)
 
-	service := NewAggregatorService(scraper)
+	service := aggregator.NewAggregatorService(scraper)
 	ctx := context.Background()
 
-	result, err := service.FetchJobs(ctx, "senior golang", "location")
+	result, err := service.FetchJobs(ctx, model.SearchCriteria{Query: "senior golang", Location: "location"})
 
 	assert.NoError(t, err)
 	assert.Equal(t, 1, len(result))
@@ -238,16 +239,16 @@ func TestFetchJobsWithDifferentQueries(t *testing.T) {
 	scraper := mocks.NewJobScraper(t)
 	jobs := []model.Job{{ID: "1", Title: "Python Dev", Company: "Corp", Source: "source"}}
 
-	scraper.On("Fetch", mock.Anything, "python", "location").Return(jobs, nil)
+	scraper.On("Fetch", mock.Anything, model.SearchCriteria{Query: "python", Location: "location"}).Return(jobs, nil)
 
-	service := NewAggregatorService(scraper)
+	service := aggregator.NewAggregatorService(scraper)
 	ctx := context.Background()
 
-	result, err := service.FetchJobs(ctx, "python", "location")
+	result, err := service.FetchJobs(ctx, model.SearchCriteria{Query: "python", Location: "location"})
 
 	assert.NoError(t, err)
 	assert.Equal(t, 1, len(result))
-	scraper.AssertCalled(t, "Fetch", mock.Anything, "python", "location")
+	scraper.AssertCalled(t, "Fetch", mock.Anything, model.SearchCriteria{Query: "python", Location: "location"})
 }
 
 // TestFetchJobsLargeResultSet tests aggregating 200 jobs
@@ -263,14 +264,417 @@ func TestFetchJobsLargeResultSet(t *testing.T) {
 		jobs2[i] = model.Job{ID: string(rune(i + 101)), Title: "Job", Company: "Corp2", Source: "source2"}
 	}
 
-	scraper1.On("Fetch", mock.Anything, "test", "location").Return(jobs1, nil)
-	scraper2.On("Fetch", mock.Anything, "test", "location").Return(jobs2, nil)
+	scraper1.On("Fetch", mock.Anything, model.SearchCriteria{Query: "test", Location: "location"}).Return(jobs1, nil)
+	scraper2.On("Fetch", mock.Anything, model.SearchCriteria{Query: "test", Location: "location"}).Return(jobs2, nil)
 
-	service := NewAggregatorService(scraper1, scraper2)
+	service := aggregator.NewAggregatorService(scraper1, scraper2)
 	ctx := context.Background()
 
-	result, err := service.FetchJobs(ctx, "test", "location")
+	result, err := service.FetchJobs(ctx, model.SearchCriteria{Query: "test", Location: "location"})
 
 	assert.NoError(t, err)
 	assert.Equal(t, 200, len(result))
 }
+
+// TestAggregateBestEffortPartialFailure tests that successful sources are kept when another source fails
+func TestAggregateBestEffortPartialFailure(t *testing.T) {
+	scraper1 := mocks.NewJobScraper(t)
+	scraper2 := mocks.NewJobScraper(t)
+
+	jobs := []model.Job{{ID: "1", Title: "Go Dev", Company: "Corp1", Source: "indeed"}}
+
+	scraper1.On("Fetch", mock.Anything, model.SearchCriteria{Query: "golang", Location: "location"}).Return(jobs, nil)
+	scraper2.On("Fetch", mock.Anything, model.SearchCriteria{Query: "golang", Location: "location"}).Return(nil, errors.New("selector changed"))
+
+	service := aggregator.NewAggregatorServiceWithOptions(
+		[]aggregator.JobScraper{scraper1, scraper2},
+		aggregator.WithPolicy(aggregator.BestEffort),
+	)
+
+	result, err := service.Aggregate(context.Background(), model.SearchCriteria{Query: "golang", Location: "location"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, jobs, result.Jobs)
+	assert.Len(t, result.Reports, 2)
+	assert.Equal(t, "source #1", result.Reports[0].Source)
+	assert.Equal(t, 1, result.Reports[0].Jobs)
+	assert.NoError(t, result.Reports[0].Err)
+	assert.Equal(t, "source #2", result.Reports[1].Source)
+	assert.EqualError(t, result.Reports[1].Err, "selector changed")
+	assert.Equal(t, 1, result.Succeeded())
+
+	var srcErr *aggregator.SourceError
+	assert.ErrorAs(t, result.Err(), &srcErr)
+	assert.Equal(t, jobs, srcErr.Jobs())
+	assert.Len(t, srcErr.Failed(), 1)
+	assert.Equal(t, "1 of 2 source(s) failed: source #2: selector changed", srcErr.Error())
+}
+
+// TestAggregateBestEffortPartialJobs tests that jobs returned along with an error are kept
+// while the source is still reported as failed
+func TestAggregateBestEffortPartialJobs(t *testing.T) {
+	scraper := mocks.NewJobScraper(t)
+
+	jobs := []model.Job{{ID: "1", Title: "Go Dev", Company: "Corp1", Source: "linkedin"}}
+
+	scraper.On("Fetch", mock.Anything, model.SearchCriteria{Query: "golang", Location: "location"}).Return(jobs, errors.New("page 2: timeout"))
+
+	service := aggregator.NewAggregatorServiceWithOptions(
+		[]aggregator.JobScraper{scraper},
+		aggregator.WithPolicy(aggregator.BestEffort),
+	)
+
+	result, err := service.Aggregate(context.Background(), model.SearchCriteria{Query: "golang", Location: "location"})
+
+	assert.NoError(t, err)
+	assert.Error(t, result.Err())
+	assert.Equal(t, jobs, result.Jobs)
+	assert.Equal(t, 1, result.Reports[0].Jobs)
+	assert.True(t, result.Reports[0].Failed())
+	assert.Equal(t, 0, result.Succeeded())
+}
+
+// TestAggregateBestEffortNoFailures tests that Result.Err is nil when every source succeeds
+func TestAggregateBestEffortNoFailures(t *testing.T) {
+	scraper := mocks.NewJobScraper(t)
+	scraper.On("Fetch", mock.Anything, model.SearchCriteria{Query: "golang", Location: "location"}).Return([]model.Job{{ID: "1"}}, nil)
+
+	service := aggregator.NewAggregatorServiceWithOptions(
+		[]aggregator.JobScraper{scraper},
+		aggregator.WithPolicy(aggregator.BestEffort),
+	)
+
+	result, err := service.Aggregate(context.Background(), model.SearchCriteria{Query: "golang", Location: "location"})
+
+	assert.NoError(t, err)
+	assert.NoError(t, result.Err())
+	assert.Empty(t, result.Failed())
+}
+
+// TestAggregateRequireAtLeast tests that the minimum number of successful sources is enforced
+func TestAggregateRequireAtLeast(t *testing.T) {
+	scraper1 := mocks.NewJobScraper(t)
+	scraper2 := mocks.NewJobScraper(t)
+	scraper3 := mocks.NewJobScraper(t)
+
+	scraper1.On("Fetch", mock.Anything, model.SearchCriteria{Query: "golang", Location: "location"}).Return([]model.Job{{ID: "1"}}, nil)
+	scraper2.On("Fetch", mock.Anything, model.SearchCriteria{Query: "golang", Location: "location"}).Return(nil, errors.New("blocked"))
+	scraper3.On("Fetch", mock.Anything, model.SearchCriteria{Query: "golang", Location: "location"}).Return(nil, errors.New("timeout"))
+
+	scrapers := []aggregator.JobScraper{scraper1, scraper2, scraper3}
+
+	service := aggregator.NewAggregatorServiceWithOptions(scrapers, aggregator.WithPolicy(aggregator.RequireAtLeast(1)))
+	result, err := service.Aggregate(context.Background(), model.SearchCriteria{Query: "golang", Location: "location"})
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Jobs, 1)
+
+	service = aggregator.NewAggregatorServiceWithOptions(scrapers, aggregator.WithPolicy(aggregator.RequireAtLeast(2)))
+	result, err = service.Aggregate(context.Background(), model.SearchCriteria{Query: "golang", Location: "location"})
+
+	var srcErr *aggregator.SourceError
+	assert.ErrorAs(t, err, &srcErr)
+	assert.Len(t, srcErr.Failed(), 2)
+	assert.Len(t, result.Jobs, 1)
+}
+
+// namedScraper is a JobScraper that also reports its source name
+type namedScraper struct {
+	*mocks.JobScraper
+	name string
+}
+
+func (s namedScraper) Name() string {
+	return s.name
+}
+
+// TestAggregateUsesScraperName tests that scrapers implementing Named are reported by name
+func TestAggregateUsesScraperName(t *testing.T) {
+	scraper := mocks.NewJobScraper(t)
+	scraper.On("Fetch", mock.Anything, model.SearchCriteria{Query: "golang", Location: "location"}).Return(nil, errors.New("captcha"))
+
+	service := aggregator.NewAggregatorServiceWithOptions(
+		[]aggregator.JobScraper{namedScraper{JobScraper: scraper, name: "LinkedIn"}},
+		aggregator.WithPolicy(aggregator.BestEffort),
+	)
+
+	result, err := service.Aggregate(context.Background(), model.SearchCriteria{Query: "golang", Location: "location"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "LinkedIn", result.Reports[0].Source)
+	assert.EqualError(t, result.Err(), "1 of 1 source(s) failed: LinkedIn: captcha")
+}
+
+// TestStreamEmitsBatchesThenDone tests that each source is streamed as it finishes, followed by a completion event
+func TestStreamEmitsBatchesThenDone(t *testing.T) {
+	scraper1 := mocks.NewJobScraper(t)
+	scraper2 := mocks.NewJobScraper(t)
+
+	releaseScraper2 := make(chan struct{})
+
+	scraper1.On("Fetch", mock.Anything, model.SearchCriteria{Query: "golang", Location: "location"}).Return([]model.Job{{ID: "1"}}, nil)
+	scraper2.On("Fetch", mock.Anything, model.SearchCriteria{Query: "golang", Location: "location"}).
+		Run(func(args mock.Arguments) {
+			<-releaseScraper2
+		}).
+		Return([]model.Job{{ID: "2"}, {ID: "3"}}, nil)
+
+	service := aggregator.NewAggregatorService(scraper1, scraper2)
+	events := service.Stream(context.Background(), model.SearchCriteria{Query: "golang", Location: "location"})
+
+	// scraper1 must be delivered while scraper2 is still running
+	first := <-events
+	assert.False(t, first.Done)
+	assert.Equal(t, "source #1", first.Report.Source)
+	assert.Equal(t, []model.Job{{ID: "1"}}, first.Jobs)
+
+	close(releaseScraper2)
+
+	second := <-events
+	assert.False(t, second.Done)
+	assert.Equal(t, 2, second.Report.Jobs)
+
+	done := <-events
+	assert.True(t, done.Done)
+	assert.NoError(t, done.Err)
+	assert.Len(t, done.Result.Jobs, 3)
+
+	_, open := <-events
+	assert.False(t, open)
+}
+
+// TestStreamFailFast tests that the stream completes with the first error under the default policy
+func TestStreamFailFast(t *testing.T) {
+	scraper := mocks.NewJobScraper(t)
+	scraper.On("Fetch", mock.Anything, model.SearchCriteria{Query: "golang", Location: "location"}).Return(nil, errors.New("network error"))
+
+	service := aggregator.NewAggregatorService(scraper)
+
+	var events []aggregator.Event
+	for event := range service.Stream(context.Background(), model.SearchCriteria{Query: "golang", Location: "location"}) {
+		events = append(events, event)
+	}
+
+	assert.Len(t, events, 2)
+	assert.EqualError(t, events[0].Report.Err, "network error")
+	assert.Empty(t, events[0].Jobs)
+	assert.True(t, events[1].Done)
+	assert.EqualError(t, events[1].Err, "network error")
+	assert.Nil(t, events[1].Result)
+}
+
+// enrichingScraper is a JobScraper that fills in the description of its jobs
+type enrichingScraper struct {
+	*mocks.JobScraper
+}
+
+func (s enrichingScraper) Enrich(ctx context.Context, job *model.Job) error {
+	if job.ID == "broken" {
+		job.Description = "partially enriched"
+		return errors.New("detail page unavailable")
+	}
+	job.Description = "Full description of " + job.Title
+	return nil
+}
+
+// TestAggregateWithEnrichment tests that jobs are enriched and failed enrichments keep the original job
+func TestAggregateWithEnrichment(t *testing.T) {
+	scraper := mocks.NewJobScraper(t)
+	scraper.On("Fetch", mock.Anything, model.SearchCriteria{Query: "golang", Location: "location"}).Return([]model.Job{
+		{ID: "1", Title: "Go Dev"},
+		{ID: "broken", Title: "Rust Dev"},
+	}, nil)
+
+	service := aggregator.NewAggregatorServiceWithOptions(
+		[]aggregator.JobScraper{enrichingScraper{JobScraper: scraper}},
+		aggregator.WithEnrichment(2),
+	)
+
+	result, err := service.FetchJobs(context.Background(), model.SearchCriteria{Query: "golang", Location: "location"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Full description of Go Dev", result[0].Description)
+	assert.Empty(t, result[1].Description)
+}
+
+// TestAggregateWithoutEnrichment tests that enrichment is opt-in
+func TestAggregateWithoutEnrichment(t *testing.T) {
+	scraper := mocks.NewJobScraper(t)
+	scraper.On("Fetch", mock.Anything, model.SearchCriteria{Query: "golang", Location: "location"}).Return([]model.Job{{ID: "1", Title: "Go Dev"}}, nil)
+
+	service := aggregator.NewAggregatorService(enrichingScraper{JobScraper: scraper})
+
+	result, err := service.FetchJobs(context.Background(), model.SearchCriteria{Query: "golang", Location: "location"})
+
+	assert.NoError(t, err)
+	assert.Empty(t, result[0].Description)
+}
+
+// TestFetchJobsParsesSalary tests that salary text is normalized into structured pay
+func TestFetchJobsParsesSalary(t *testing.T) {
+	scraper := mocks.NewJobScraper(t)
+	scraper.On("Fetch", mock.Anything, model.SearchCriteria{Query: "golang", Location: "location"}).Return([]model.Job{
+		{ID: "1", Salary: "$50 an hour"},
+		{ID: "2", Salary: "Competitive"},
+	}, nil)
+
+	service := aggregator.NewAggregatorService(scraper)
+
+	result, err := service.FetchJobs(context.Background(), model.SearchCriteria{Query: "golang", Location: "location"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, model.Salary{Min: 50, Max: 50, Currency: "USD", Period: model.PeriodHour}, result[0].Pay)
+	assert.True(t, result[1].Pay.IsZero())
+}
+
+// filteringScraper is a JobScraper that cannot filter by salary
+type filteringScraper struct {
+	*mocks.JobScraper
+}
+
+func (s filteringScraper) Unsupported(criteria model.SearchCriteria) []string {
+	if criteria.MinSalary > 0 {
+		return []string{model.CriterionMinSalary}
+	}
+	return nil
+}
+
+// TestAggregateReportsUnsupportedCriteria tests that criteria a source can't honor are reported
+func TestAggregateReportsUnsupportedCriteria(t *testing.T) {
+	criteria := model.SearchCriteria{Query: "golang", Workplace: model.WorkplaceRemote, MinSalary: 100000}
+
+	scraper := mocks.NewJobScraper(t)
+	scraper.On("Fetch", mock.Anything, criteria).Return([]model.Job{{ID: "1"}}, nil)
+
+	service := aggregator.NewAggregatorService(filteringScraper{JobScraper: scraper})
+
+	result, err := service.Aggregate(context.Background(), criteria)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{model.CriterionMinSalary}, result.Reports[0].Unsupported)
+}
+
+// TestAggregateFiltersMinSalary tests that jobs with a known pay are filtered on the
+// minimum salary a source couldn't filter on
+func TestAggregateFiltersMinSalary(t *testing.T) {
+	criteria := model.SearchCriteria{Query: "golang", MinSalary: 100000}
+
+	scraper := mocks.NewJobScraper(t)
+	scraper.On("Fetch", mock.Anything, criteria).Return([]model.Job{
+		{ID: "1", Salary: "$60 an hour"},
+		{ID: "2", Salary: "$80,000 a year"},
+		{ID: "3", Salary: "From $90,000"},
+		{ID: "4", Salary: "€7,000 - €9,000 a month"},
+	}, nil)
+
+	service := aggregator.NewAggregatorService(filteringScraper{JobScraper: scraper})
+
+	result, err := service.Aggregate(context.Background(), criteria)
+
+	assert.NoError(t, err)
+	ids := make([]string, len(result.Jobs))
+	for i, job := range result.Jobs {
+		ids[i] = job.ID
+	}
+	assert.Equal(t, []string{"1", "3", "4"}, ids, "pay is compared annualized, a lower bound alone is kept")
+	assert.Equal(t, 3, result.Reports[0].Jobs)
+	assert.Empty(t, result.Reports[0].Unsupported, "every pay was known, so the criterion was honored")
+}
+
+// TestAggregateStoresJobs tests that the run of every source is recorded, telling failed sources apart
+func TestAggregateStoresJobs(t *testing.T) {
+	criteria := model.SearchCriteria{Query: "golang"}
+	jobs := []model.Job{{ID: "1", Title: "Go Dev"}}
+
+	found := mocks.NewJobScraper(t)
+	found.On("Fetch", mock.Anything, criteria).Return(jobs, nil)
+	empty := mocks.NewJobScraper(t)
+	empty.On("Fetch", mock.Anything, criteria).Return(nil, nil)
+	failed := mocks.NewJobScraper(t)
+	failed.On("Fetch", mock.Anything, criteria).Return(nil, errors.New("blocked"))
+
+	repo := mocks.NewJobRepository(t)
+	repo.On("RecordRun", mock.Anything, criteria, []model.SourceRun{
+		{Source: "source #1", Jobs: jobs},
+		{Source: "source #2"},
+		{Source: "source #3", Failed: true},
+	}).Return(nil)
+
+	service := aggregator.NewAggregatorServiceWithOptions([]aggregator.JobScraper{found, empty, failed},
+		aggregator.WithRepository(repo), aggregator.WithPolicy(aggregator.BestEffort))
+
+	result, err := service.Aggregate(context.Background(), criteria)
+
+	assert.NoError(t, err)
+	assert.NoError(t, result.StoreErr)
+}
+
+// TestAggregateStoresEmptyRuns tests that a run without any job is still recorded
+func TestAggregateStoresEmptyRuns(t *testing.T) {
+	criteria := model.SearchCriteria{Query: "golang"}
+
+	scraper := mocks.NewJobScraper(t)
+	scraper.On("Fetch", mock.Anything, criteria).Return(nil, nil)
+
+	repo := mocks.NewJobRepository(t)
+	repo.On("RecordRun", mock.Anything, criteria, []model.SourceRun{{Source: "source #1"}}).Return(nil)
+
+	service := aggregator.NewAggregatorServiceWithOptions([]aggregator.JobScraper{scraper}, aggregator.WithRepository(repo))
+
+	result, err := service.Aggregate(context.Background(), criteria)
+
+	assert.NoError(t, err)
+	assert.Empty(t, result.Jobs)
+}
+
+// TestAggregateStoreFailureIsReported tests that a repository error doesn't fail the aggregation
+func TestAggregateStoreFailureIsReported(t *testing.T) {
+	criteria := model.SearchCriteria{Query: "golang"}
+	jobs := []model.Job{{ID: "1", Title: "Go Dev"}}
+	storeErr := errors.New("disk full")
+
+	scraper := mocks.NewJobScraper(t)
+	scraper.On("Fetch", mock.Anything, criteria).Return(jobs, nil)
+
+	repo := mocks.NewJobRepository(t)
+	repo.On("RecordRun", mock.Anything, criteria, []model.SourceRun{{Source: "source #1", Jobs: jobs}}).Return(storeErr)
+
+	service := aggregator.NewAggregatorServiceWithOptions([]aggregator.JobScraper{scraper}, aggregator.WithRepository(repo))
+
+	result, err := service.Aggregate(context.Background(), criteria)
+
+	assert.NoError(t, err)
+	assert.Equal(t, jobs, result.Jobs)
+	assert.ErrorIs(t, result.StoreErr, storeErr)
+}
+
+// TestAggregateWithDedup tests that a job found on two sources is merged into one
+func TestAggregateWithDedup(t *testing.T) {
+	criteria := model.SearchCriteria{Query: "golang"}
+
+	indeed := mocks.NewJobScraper(t)
+	indeed.On("Fetch", mock.Anything, criteria).Return([]model.Job{
+		{ID: "1", Title: "Go Developer", Company: "Acme Inc.", Location: "Berlin", Source: "Indeed"},
+	}, nil)
+
+	linkedin := mocks.NewJobScraper(t)
+	linkedin.On("Fetch", mock.Anything, criteria).Return([]model.Job{
+		{ID: "2", Title: "Go Developer", Company: "Acme", Location: "Berlin, Germany", Source: "LinkedIn"},
+		{ID: "3", Title: "Designer", Company: "Acme", Location: "Berlin", Source: "LinkedIn"},
+	}, nil)
+
+	service := aggregator.NewAggregatorServiceWithOptions([]aggregator.JobScraper{indeed, linkedin}, aggregator.WithDedup())
+
+	result, err := service.Aggregate(context.Background(), criteria)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Jobs, 2)
+	assert.Equal(t, 1, result.Reports[0].Jobs)
+	assert.Equal(t, 2, result.Reports[1].Jobs)
+
+	for _, job := range result.Jobs {
+		if job.Title == "Go Developer" {
+			assert.ElementsMatch(t, []string{"Indeed", "LinkedIn"}, job.Sources())
+		}
+	}
+}
@@ -0,0 +1,10 @@
+package aggregator
+
+// WithDedup merges the jobs found on more than one source into a single job once
+// every scraper finished, see dedup.Merge. Stream batches are left as scraped; only
+// Result.Jobs is merged. The repository still receives every listing.
+func WithDedup() Option {
+	return func(a *aggregatorService) {
+		a.dedup = true
+	}
+}
@@ -0,0 +1,67 @@
+package aggregator
+
+import (
+	"context"
+	"sync"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/salary"
+)
+
+// Enricher is implemented by scrapers that can fill in a job's details
+// (description, salary, ...) by visiting its detail page.
+type Enricher interface {
+	Enrich(ctx context.Context, job *model.Job) error
+}
+
+// WithEnrichment enables the enrichment stage: once a scraper that implements
+// Enricher returns its jobs, each job is enriched before being aggregated, with at
+// most concurrency detail pages visited at the same time across all scrapers.
+func WithEnrichment(concurrency int) Option {
+	return func(a *aggregatorService) {
+		if concurrency <= 0 {
+			concurrency = 1
+		}
+		a.enrichSlots = concurrency
+	}
+}
+
+// enrich runs the enricher over jobs in place. A job that cannot be enriched is
+// kept as the scraper returned it.
+func enrich(ctx context.Context, enricher Enricher, sem chan struct{}, jobs []model.Job) {
+	var wg sync.WaitGroup
+
+	for i := range jobs {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			job := jobs[i]
+			if err := enricher.Enrich(ctx, &job); err == nil {
+				jobs[i] = job
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+// normalizePay parses the salary text of jobs whose structured pay is unknown.
+func normalizePay(jobs []model.Job) {
+	for i := range jobs {
+		if !jobs[i].Pay.IsZero() || jobs[i].Salary == "" {
+			continue
+		}
+		if pay, ok := salary.Parse(jobs[i].Salary); ok {
+			jobs[i].Pay = pay
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package aggregator
+
+import (
+	"context"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+)
+
+// JobRepository records every aggregation with what each source found. store.Repository satisfies it.
+//
+//go:generate mockery --name=JobRepository --output=../../mocks --outpkg=mocks --filename=job_repository_mock.go
+type JobRepository interface {
+	RecordRun(ctx context.Context, criteria model.SearchCriteria, sources []model.SourceRun) error
+}
+
+// WithRepository records every aggregation into repo once all scrapers finished,
+// including those that found nothing. A failure to store it doesn't fail the
+// aggregation: it is reported through Result.StoreErr instead.
+func WithRepository(repo JobRepository) Option {
+	return func(a *aggregatorService) {
+		a.repo = repo
+	}
+}
+
+// persist records the run of every source, if a repository is configured. Runs
+// tell failed sources apart, for the repository not to take their jobs for gone.
+func (a *aggregatorService) persist(ctx context.Context, criteria model.SearchCriteria, runs []model.SourceRun, result *Result) {
+	if a.repo == nil {
+		return
+	}
+	result.StoreErr = a.repo.RecordRun(ctx, criteria, runs)
+}
@@ -0,0 +1,33 @@
+package aggregator
+
+// Policy controls how the aggregator reacts when some of its scrapers fail.
+type Policy struct {
+	failFast   bool
+	minSources int
+}
+
+var (
+	// FailFast aborts the aggregation and returns the first scraper error.
+	FailFast = Policy{failFast: true}
+
+	// BestEffort waits for every scraper and returns whatever they produced, including
+	// the jobs failed ones read before failing.
+	// Failures are only reported through the Result.
+	BestEffort = Policy{}
+)
+
+// RequireAtLeast waits for every scraper and succeeds as long as at least n of them
+// completed without error. Otherwise a *SourceError is returned.
+func RequireAtLeast(n int) Policy {
+	return Policy{minSources: n}
+}
+
+// Option configures an aggregator service.
+type Option func(*aggregatorService)
+
+// WithPolicy sets the failure policy used by the aggregator. Defaults to FailFast.
+func WithPolicy(p Policy) Option {
+	return func(a *aggregatorService) {
+		a.policy = p
+	}
+}
@@ -0,0 +1,125 @@
+package aggregator
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+)
+
+// Named is implemented by scrapers that can report a human readable source name.
+// Scrapers that don't implement it are reported by their position instead.
+type Named interface {
+	Name() string
+}
+
+// SourceReport describes the outcome of a single scraper run.
+type SourceReport struct {
+	Source      string
+	Err         error
+	Duration    time.Duration
+	Jobs        int      // jobs returned, including those read before a failure
+	Unsupported []string // search criteria the source could not honor
+}
+
+// Failed reports whether the scraper returned an error.
+func (r SourceReport) Failed() bool {
+	return r.Err != nil
+}
+
+// Result holds the aggregated jobs together with a report for every scraper.
+// Reports are kept in the same order the scrapers were registered.
+type Result struct {
+	Jobs    []model.Job
+	Reports []SourceReport
+
+	// StoreErr is set when the jobs could not be saved to the repository, see WithRepository.
+	StoreErr error
+}
+
+// Failed returns the reports of the scrapers that returned an error.
+func (r *Result) Failed() []SourceReport {
+	var failed []SourceReport
+	for _, report := range r.Reports {
+		if report.Failed() {
+			failed = append(failed, report)
+		}
+	}
+	return failed
+}
+
+// Succeeded returns the number of scrapers that completed without error.
+func (r *Result) Succeeded() int {
+	return len(r.Reports) - len(r.Failed())
+}
+
+// Err returns a *SourceError when at least one scraper failed, nil otherwise.
+func (r *Result) Err() error {
+	if len(r.Failed()) == 0 {
+		return nil
+	}
+	return &SourceError{Result: r}
+}
+
+// Event is emitted by AggregatorService.Stream.
+// Every scraper produces one batch event when it finishes (under FailFast the stream
+// stops at the first failure), and the stream always ends with a single Done event.
+type Event struct {
+	// Report and Jobs describe the scraper that just finished. When it failed, Jobs
+	// holds those it read before the error, if any.
+	Report SourceReport
+	Jobs   []model.Job
+
+	// Done marks the completion event. Result and Err hold what Aggregate would have returned.
+	Done   bool
+	Result *Result
+	Err    error
+}
+
+// SourceError reports which sources failed during an aggregation.
+// It carries the partial result so callers can still use the jobs
+// returned, including those failed sources read before failing.
+type SourceError struct {
+	Result *Result
+}
+
+func (e *SourceError) Error() string {
+	failed := e.Failed()
+
+	parts := make([]string, len(failed))
+	for i, report := range failed {
+		parts[i] = fmt.Sprintf("%s: %v", report.Source, report.Err)
+	}
+
+	return fmt.Sprintf("%d of %d source(s) failed: %s", len(failed), len(e.Result.Reports), strings.Join(parts, "; "))
+}
+
+// Unwrap exposes the individual scraper errors to errors.Is and errors.As.
+func (e *SourceError) Unwrap() []error {
+	failed := e.Failed()
+
+	errs := make([]error, len(failed))
+	for i, report := range failed {
+		errs[i] = report.Err
+	}
+	return errs
+}
+
+// Failed returns the reports of the scrapers that returned an error.
+func (e *SourceError) Failed() []SourceReport {
+	return e.Result.Failed()
+}
+
+// Jobs returns the jobs collected from every source, including what failed
+// sources returned before failing.
+func (e *SourceError) Jobs() []model.Job {
+	return e.Result.Jobs
+}
+
+func sourceName(s JobScraper, index int) string {
+	if n, ok := s.(Named); ok {
+		return n.Name()
+	}
+	return fmt.Sprintf("source #%d", index+1)
+}
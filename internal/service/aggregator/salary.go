@@ -0,0 +1,46 @@
+package aggregator
+
+import (
+	"slices"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+)
+
+// filterMinSalary applies the minimum yearly salary of a search to the jobs of a
+// source that couldn't filter on it. Jobs whose pay is known are compared by their
+// annualized upper bound, or kept when only a lower bound is known. Jobs without a
+// known pay are kept too, and reported by known being false.
+func filterMinSalary(jobs []model.Job, minSalary int) (kept []model.Job, known bool) {
+	known = true
+	kept = jobs[:0:0]
+	for _, job := range jobs {
+		if job.Pay.IsZero() {
+			known = false
+			kept = append(kept, job)
+			continue
+		}
+
+		_, max := job.Pay.Annual()
+		if max == 0 || max >= float64(minSalary) {
+			kept = append(kept, job)
+		}
+	}
+	return kept, known
+}
+
+// applyMinSalary filters the jobs of a source on the minimum salary when the source
+// reported it couldn't. The criterion stays unsupported only for a source that
+// returned jobs without a known pay.
+func applyMinSalary(report *SourceReport, criteria model.SearchCriteria, jobs []model.Job) []model.Job {
+	if !slices.Contains(report.Unsupported, model.CriterionMinSalary) {
+		return jobs
+	}
+
+	jobs, known := filterMinSalary(jobs, criteria.MinSalary)
+	if known {
+		report.Unsupported = slices.DeleteFunc(slices.Clone(report.Unsupported), func(name string) bool {
+			return name == model.CriterionMinSalary
+		})
+	}
+	return jobs
+}
@@ -0,0 +1,257 @@
+package browser
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/launcher"
+	"github.com/go-rod/stealth"
+)
+
+// ErrPoolClosed is returned when a page is requested from a closed pool.
+var ErrPoolClosed = errors.New("browser pool is closed")
+
+// healthTimeout bounds the reset of a page put back and its check before it is handed out again.
+const healthTimeout = 5 * time.Second
+
+// Options configures the shared browser.
+type Options struct {
+	Bin       string // path to the chrome/chromium binary, auto-detected when empty
+	Headless  bool   // required for backend
+	NoSandbox bool   // needed for Docker and some Linux servers
+	Proxy     string // optional proxy server, e.g. "127.0.0.1:8080"
+	MaxPages  int    // maximum number of pages checked out at the same time
+}
+
+// DefaultOptions returns the options the scrapers have always been launched with.
+func DefaultOptions() Options {
+	return Options{
+		Headless:  true,
+		NoSandbox: true,
+		MaxPages:  4,
+	}
+}
+
+// Pool launches a single headless browser on first use and hands out stealth pages from it.
+// Pages are recycled when they are put back, the number of pages in use is capped by
+// Options.MaxPages, and the browser is relaunched if it crashed. Recycled pages are
+// checked before being handed out again: dead ones are replaced.
+type Pool struct {
+	opts Options
+
+	mu         sync.Mutex
+	launcher   *launcher.Launcher
+	browser    *rod.Browser
+	generation int
+	inUse      map[*rod.Page]int
+	closed     bool
+
+	// slots holds one entry per page that may be checked out. A nil entry is a free
+	// slot without a page yet.
+	slots chan *pooledPage
+}
+
+type pooledPage struct {
+	page       *rod.Page
+	generation int
+}
+
+// NewPool creates a pool. The browser is not launched until the first page is requested.
+func NewPool(opts Options) *Pool {
+	if opts.MaxPages <= 0 {
+		opts.MaxPages = 1
+	}
+
+	slots := make(chan *pooledPage, opts.MaxPages)
+	for i := 0; i < opts.MaxPages; i++ {
+		slots <- nil
+	}
+
+	return &Pool{
+		opts:  opts,
+		inUse: map[*rod.Page]int{},
+		slots: slots,
+	}
+}
+
+// Page checks out a stealth page, waiting for a free slot if MaxPages are already in use.
+// The page must be handed back with Put once the caller is done with it.
+func (p *Pool) Page(ctx context.Context) (*rod.Page, error) {
+	var slot *pooledPage
+	select {
+	case slot = <-p.slots:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	page, err := p.checkout(ctx, slot)
+	if err != nil {
+		p.slots <- nil
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// Put hands a page back to the pool so it can be reused.
+// Pages that belong to a browser that has since been relaunched are closed instead.
+func (p *Pool) Put(page *rod.Page) {
+	if page == nil {
+		return
+	}
+
+	p.mu.Lock()
+	generation, ok := p.inUse[page]
+	delete(p.inUse, page)
+	reusable := ok && !p.closed && generation == p.generation
+	p.mu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	// Reset the page so the next user doesn't inherit its state. A page stuck
+	// loading must not block the caller, so both calls are bounded.
+	if reusable {
+		reset := page.Timeout(healthTimeout)
+		err := reset.Navigate("about:blank")
+		reset.CancelTimeout()
+
+		if err == nil {
+			p.slots <- &pooledPage{page: page, generation: generation}
+			return
+		}
+	}
+
+	closing := page.Timeout(healthTimeout)
+	_ = closing.Close()
+	closing.CancelTimeout()
+	p.slots <- nil
+}
+
+// Close closes the browser and every pooled page. Pages that are still checked out
+// are closed along with the browser.
+func (p *Pool) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil
+	}
+	p.closed = true
+
+	return p.shutdown()
+}
+
+func (p *Pool) checkout(ctx context.Context, slot *pooledPage) (*rod.Page, error) {
+	// The page, or the browser along with it, may have crashed since it was put back.
+	// The slot is ours alone, so the check doesn't need the lock.
+	if slot != nil && !alive(ctx, slot.page) {
+		_ = slot.page.Context(ctx).Close()
+		slot = nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil, ErrPoolClosed
+	}
+
+	if slot != nil && slot.generation == p.generation {
+		p.inUse[slot.page] = slot.generation
+		return slot.page, nil
+	}
+
+	page, err := p.newPage(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, err
+		}
+
+		// The browser may have crashed: relaunch it once and try again.
+		_ = p.shutdown()
+		if page, err = p.newPage(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	p.inUse[page] = p.generation
+	return page, nil
+}
+
+// alive reports whether page still answers, which its browser must do too.
+func alive(ctx context.Context, page *rod.Page) bool {
+	ctx, cancel := context.WithTimeout(ctx, healthTimeout)
+	defer cancel()
+
+	_, err := page.Context(ctx).Eval("() => true")
+	return err == nil
+}
+
+// newPage opens a stealth page, launching the browser first if needed.
+// Callers must hold p.mu.
+func (p *Pool) newPage(ctx context.Context) (*rod.Page, error) {
+	if p.browser == nil {
+		if err := p.launch(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return stealth.Page(p.browser) // anti-bot cloak
+}
+
+// launch starts a new browser process, giving up on waiting for it when ctx is done.
+// The browser outlives ctx: it is shared by every caller. Callers must hold p.mu.
+func (p *Pool) launch(ctx context.Context) error {
+	bin := p.opts.Bin
+	if bin == "" {
+		bin, _ = launcher.LookPath() // auto-detect chrome/chromium
+	}
+
+	l := launcher.New().
+		Context(ctx).
+		Headless(p.opts.Headless).
+		NoSandbox(p.opts.NoSandbox).
+		Bin(bin)
+
+	if p.opts.Proxy != "" {
+		l = l.Proxy(p.opts.Proxy)
+	}
+
+	u, err := l.Launch()
+	if err != nil {
+		return err
+	}
+
+	browser := rod.New().ControlURL(u)
+	if err := browser.Connect(); err != nil {
+		l.Kill()
+		return err
+	}
+
+	p.launcher = l
+	p.browser = browser
+	p.generation++
+
+	return nil
+}
+
+// shutdown closes the current browser, if any. Callers must hold p.mu.
+func (p *Pool) shutdown() error {
+	if p.browser == nil {
+		return nil
+	}
+
+	err := p.browser.Close()
+	p.launcher.Kill()
+	p.launcher.Cleanup()
+
+	p.browser = nil
+	p.launcher = nil
+
+	return err
+}
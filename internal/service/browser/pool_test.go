@@ -0,0 +1,59 @@
+package browser
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPageAfterClose tests that a closed pool refuses to hand out pages without launching a browser
+func TestPageAfterClose(t *testing.T) {
+	pool := NewPool(DefaultOptions())
+	assert.NoError(t, pool.Close())
+
+	page, err := pool.Page(context.Background())
+
+	assert.Nil(t, page)
+	assert.ErrorIs(t, err, ErrPoolClosed)
+	assert.Len(t, pool.slots, DefaultOptions().MaxPages, "the slot must be handed back")
+}
+
+// TestPageWaitsForFreeSlot tests that Page honors the context while every slot is taken
+func TestPageWaitsForFreeSlot(t *testing.T) {
+	pool := NewPool(Options{MaxPages: 1})
+	<-pool.slots // simulate a page that is checked out
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	page, err := pool.Page(ctx)
+
+	assert.Nil(t, page)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// TestPageLaunchHonorsContext tests that waiting for the browser to start gives up with the context
+func TestPageLaunchHonorsContext(t *testing.T) {
+	// A browser that never prints its DevTools URL.
+	bin := filepath.Join(t.TempDir(), "chrome")
+	require.NoError(t, os.WriteFile(bin, []byte("#!/bin/sh\nsleep 30\n"), 0o755))
+
+	pool := NewPool(Options{Bin: bin, Headless: true, MaxPages: 1})
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	page, err := pool.Page(ctx)
+
+	assert.Nil(t, page)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 10*time.Second, "the launch is not retried once the context is done")
+	assert.Len(t, pool.slots, 1, "the slot must be handed back")
+}
@@ -0,0 +1,200 @@
+package ashby
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+)
+
+// DefaultBaseURL is Ashby's public posting API.
+const DefaultBaseURL = "https://api.ashbyhq.com"
+
+const (
+	sourceName = "Ashby"
+
+	// boardPath lists every job of a board, with compensation.
+	boardPath = "/posting-api/job-board/%s?includeCompensation=true"
+)
+
+// board is the response of the job board endpoint.
+type board struct {
+	Jobs []posting `json:"jobs"`
+}
+
+type posting struct {
+	ID                 string `json:"id"`
+	Title              string `json:"title"`
+	Department         string `json:"department"`
+	Team               string `json:"team"`
+	EmploymentType     string `json:"employmentType"` // e.g. "FullTime"
+	Location           string `json:"location"`
+	SecondaryLocations []struct {
+		Location string `json:"location"`
+	} `json:"secondaryLocations"`
+	IsListed         bool   `json:"isListed"`
+	IsRemote         bool   `json:"isRemote"`
+	WorkplaceType    string `json:"workplaceType"` // "OnSite", "Hybrid" or "Remote"; missing from older boards
+	DescriptionPlain string `json:"descriptionPlain"`
+	DescriptionHTML  string `json:"descriptionHtml"`
+	PublishedAt      string `json:"publishedAt"`
+	JobURL           string `json:"jobUrl"`
+	ApplyURL         string `json:"applyUrl"`
+	Compensation     *struct {
+		Summary           string `json:"compensationTierSummary"`             // e.g. "$120K – $150K • Offers Equity"
+		SalarySummary     string `json:"scrapeableCompensationSalarySummary"` // e.g. "$120K - $150K"
+		SummaryComponents []struct {
+			CompensationType string   `json:"compensationType"` // "Salary", "EquityPercentage", ...
+			Interval         string   `json:"interval"`         // e.g. "1 YEAR"
+			CurrencyCode     string   `json:"currencyCode"`
+			MinValue         *float64 `json:"minValue"`
+			MaxValue         *float64 `json:"maxValue"`
+		} `json:"summaryComponents"`
+	} `json:"compensation"`
+}
+
+var workplaces = map[string]model.WorkplaceType{
+	"OnSite": model.WorkplaceOnSite,
+	"Hybrid": model.WorkplaceHybrid,
+	"Remote": model.WorkplaceRemote,
+}
+
+var employmentTypes = map[string]string{
+	"FullTime":  "Full-time",
+	"PartTime":  "Part-time",
+	"Intern":    "Internship",
+	"Contract":  "Contract",
+	"Temporary": "Temporary",
+}
+
+// periods maps the intervals of compensation components, e.g. "1 YEAR".
+var periods = map[string]model.SalaryPeriod{
+	"1 HOUR":  model.PeriodHour,
+	"1 DAY":   model.PeriodDay,
+	"1 WEEK":  model.PeriodWeek,
+	"1 MONTH": model.PeriodMonth,
+	"1 YEAR":  model.PeriodYear,
+}
+
+// Fetch reads every board and keeps the jobs matching the criteria.
+func Fetch(ctx context.Context, client *http.Client, baseURL string, boards []string, paging scraper.Paging, criteria model.SearchCriteria) ([]model.Job, error) {
+	now := time.Now()
+
+	return scraper.FetchCompanies(ctx, sourceName, boards, paging, jobKey, func(ctx context.Context, name string) ([]model.Job, error) {
+		var b board
+		if err := scraper.GetJSON(ctx, client, sourceName, baseURL+fmt.Sprintf(boardPath, url.PathEscape(name)), &b); err != nil {
+			return nil, err
+		}
+
+		var jobs []model.Job
+		for _, p := range b.Jobs {
+			if !p.IsListed {
+				continue
+			}
+			if matches(criteria, p, now) {
+				jobs = append(jobs, toJob(name, p))
+			}
+		}
+		return jobs, nil
+	})
+}
+
+func toJob(company string, p posting) model.Job {
+	job := model.Job{
+		ID:             p.ID,
+		Title:          p.Title,
+		Company:        company, // boards don't name the company
+		Location:       p.Location,
+		Url:            p.JobURL,
+		ApplyUrl:       p.ApplyURL,
+		Source:         sourceName,
+		Description:    p.DescriptionPlain,
+		EmploymentType: employmentTypes[p.EmploymentType],
+		Department:     scraper.Department(p.Department, p.Team),
+		Workplace:      workplace(p),
+	}
+
+	if job.Description == "" {
+		job.Description = scraper.HTMLText(p.DescriptionHTML)
+	}
+
+	if posted, err := time.Parse(time.RFC3339, p.PublishedAt); err == nil {
+		job.PostedAt = posted.UTC()
+	}
+
+	if c := p.Compensation; c != nil {
+		job.Salary = c.SalarySummary
+		if job.Salary == "" {
+			job.Salary = c.Summary
+		}
+
+		for _, comp := range c.SummaryComponents {
+			if comp.CompensationType != "Salary" {
+				continue
+			}
+			job.Pay = model.Salary{Currency: comp.CurrencyCode, Period: periods[comp.Interval]}
+			if comp.MinValue != nil {
+				job.Pay.Min = *comp.MinValue
+			}
+			if comp.MaxValue != nil {
+				job.Pay.Max = *comp.MaxValue
+			}
+			break
+		}
+	}
+
+	return job
+}
+
+// workplace prefers the workplace type and falls back to the remote flag of older boards.
+func workplace(p posting) model.WorkplaceType {
+	if w, ok := workplaces[p.WorkplaceType]; ok {
+		return w
+	}
+	if p.IsRemote {
+		return model.WorkplaceRemote
+	}
+	return ""
+}
+
+// matches filters the board client-side, since the API returns every job of a board.
+func matches(criteria model.SearchCriteria, p posting, now time.Time) bool {
+	locations := []string{p.Location}
+	for _, l := range p.SecondaryLocations {
+		locations = append(locations, l.Location)
+	}
+
+	w := workplace(p)
+	if criteria.Workplace != "" && w != "" && w != criteria.Workplace {
+		return false
+	}
+
+	var posted time.Time
+	if t, err := time.Parse(time.RFC3339, p.PublishedAt); err == nil {
+		posted = t
+	}
+
+	return scraper.MatchQuery(criteria.Query, p.Title, p.Department, p.Team) &&
+		scraper.MatchLocation(criteria.Location, strings.Join(locations, " / "), w == model.WorkplaceRemote) &&
+		scraper.MatchPosted(criteria.PostedWithin, posted, now)
+}
+
+// unsupported lists the criteria matches ignores.
+func unsupported(c model.SearchCriteria) []string {
+	var names []string
+	for _, name := range c.Criteria() {
+		if name != model.CriterionWorkplace && name != model.CriterionPostedWithin {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func jobKey(job model.Job) string {
+	return job.ID
+}
@@ -0,0 +1,86 @@
+package ashby
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+	"github.com/brandoyts/job-aggr/internal/service/scraper/scrapertest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newServer serves the board fixture for the "acme" board and fails every other one
+func newServer(t *testing.T) *httptest.Server {
+	return scrapertest.NewServer(t, map[string]string{"/posting-api/job-board/acme": "testdata/board.json"}, func(r *http.Request) {
+		assert.Equal(t, "true", r.URL.Query().Get("includeCompensation"))
+	})
+}
+
+// TestFetchMapsJobs tests that listed jobs are mapped along with their compensation and workplace
+func TestFetchMapsJobs(t *testing.T) {
+	srv := newServer(t)
+
+	jobs, err := Fetch(context.Background(), nil, srv.URL, []string{"acme"}, scraper.Paging{}, model.SearchCriteria{})
+	require.NoError(t, err)
+	require.Len(t, jobs, 2, "unlisted jobs are skipped")
+
+	assert.Equal(t, model.Job{
+		ID:             "3f8b2a4e-1c2d-4e5f-8a9b-0c1d2e3f4a5b",
+		Title:          "Senior Software Engineer",
+		Company:        "acme",
+		Location:       "San Francisco, CA",
+		Url:            "https://jobs.ashbyhq.com/acme/3f8b2a4e-1c2d-4e5f-8a9b-0c1d2e3f4a5b",
+		ApplyUrl:       "https://jobs.ashbyhq.com/acme/3f8b2a4e-1c2d-4e5f-8a9b-0c1d2e3f4a5b/application",
+		Source:         "Ashby",
+		Salary:         "$180K - $220K",
+		Description:    "Scale our Go services.",
+		EmploymentType: "Full-time",
+		Department:     "Engineering / Infrastructure",
+		Workplace:      model.WorkplaceHybrid,
+		PostedAt:       time.Date(2024, 5, 8, 16, 20, 31, 125000000, time.UTC),
+		Pay:            model.Salary{Min: 180000, Max: 220000, Currency: "USD", Period: model.PeriodYear},
+	}, jobs[0])
+
+	advocate := jobs[1]
+	assert.Equal(t, model.WorkplaceRemote, advocate.Workplace, "the remote flag is used without a workplace type")
+	assert.Equal(t, "Write tutorials.", advocate.Description)
+	assert.Equal(t, "Contract", advocate.EmploymentType)
+	assert.Equal(t, "Marketing", advocate.Department)
+	assert.Empty(t, advocate.Salary)
+	assert.True(t, advocate.Pay.IsZero())
+}
+
+// TestFetchFilters tests that boards are filtered by the search criteria
+func TestFetchFilters(t *testing.T) {
+	srv := newServer(t)
+
+	tests := []struct {
+		name     string
+		criteria model.SearchCriteria
+		want     []string
+	}{
+		{"query matches team", model.SearchCriteria{Query: "infrastructure engineer"}, []string{"Senior Software Engineer"}},
+		{"secondary location", model.SearchCriteria{Location: "New York"}, []string{"Senior Software Engineer"}},
+		{"remote location", model.SearchCriteria{Location: "Remote"}, []string{"Developer Advocate"}},
+		{"workplace", model.SearchCriteria{Workplace: model.WorkplaceHybrid}, []string{"Senior Software Engineer"}},
+		{"no match", model.SearchCriteria{Query: "rust"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobs, err := Fetch(context.Background(), nil, srv.URL, []string{"acme"}, scraper.Paging{}, tt.criteria)
+			require.NoError(t, err)
+
+			var titles []string
+			for _, job := range jobs {
+				titles = append(titles, job.Title)
+			}
+			assert.Equal(t, tt.want, titles)
+		})
+	}
+}
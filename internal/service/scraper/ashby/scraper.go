@@ -0,0 +1,23 @@
+// Package ashby reads the job boards companies host on Ashby through its public
+// posting API, without a browser.
+package ashby
+
+import (
+	"context"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+)
+
+// NewScraper creates a scraper reading the given job boards, identified by the
+// board's name: the "acme" of jobs.ashbyhq.com/acme.
+func NewScraper(companies []string, opts ...scraper.Option) *scraper.Scraper {
+	return scraper.NewScraper(scraper.Definition{
+		Name: sourceName,
+		Fetch: func(ctx context.Context, s scraper.Settings, criteria model.SearchCriteria) ([]model.Job, error) {
+			return Fetch(ctx, s.Client, s.BaseURL, companies, s.Paging, criteria)
+		},
+		Unsupported: unsupported,
+		Defaults:    scraper.Settings{BaseURL: DefaultBaseURL},
+	}, opts...)
+}
@@ -0,0 +1,54 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+)
+
+// FetchCompanies calls fetch for each company a source is configured with and
+// collects the jobs it returns. A company that cannot be read doesn't stop the
+// others: the jobs of those that could be are returned along with the error of
+// every company that couldn't, e.g. a mistyped board token.
+func FetchCompanies(ctx context.Context, source string, companies []string, paging Paging, key func(model.Job) string,
+	fetch func(ctx context.Context, company string) ([]model.Job, error)) ([]model.Job, error) {
+	if len(companies) == 0 {
+		return nil, NewError(source, ErrNotConfigured, "no companies", nil)
+	}
+
+	collector := NewCollector(paging, key)
+
+	var errs []error
+	for _, company := range companies {
+		jobs, err := fetch(ctx, company)
+		if err != nil {
+			if ctx.Err() != nil {
+				return collector.Jobs(), err
+			}
+			errs = append(errs, err)
+			continue
+		}
+
+		collector.Add(jobs)
+		if collector.Full() {
+			break
+		}
+	}
+
+	return collector.Jobs(), errors.Join(errs...)
+}
+
+// Department joins the department and team an applicant tracking system files a
+// job under, e.g. "Engineering / Platform". Either may be empty.
+func Department(department string, team string) string {
+	department, team = strings.TrimSpace(department), strings.TrimSpace(team)
+	switch {
+	case department == "" || strings.EqualFold(department, team):
+		return team
+	case team == "":
+		return department
+	}
+	return department + " / " + team
+}
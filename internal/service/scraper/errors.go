@@ -0,0 +1,61 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors describing why a scraper failed. Use errors.Is to test for them.
+var (
+	ErrLaunch            = errors.New("browser launch failed")
+	ErrNavigation        = errors.New("navigation failed")
+	ErrNavigationTimeout = errors.New("navigation timed out")
+	ErrBlocked           = errors.New("blocked by anti-bot protection")
+	ErrSelectorNotFound  = errors.New("selector not found")
+	ErrEmptyPage         = errors.New("empty page")
+	ErrInvalidResponse   = errors.New("invalid response")
+	ErrNotConfigured     = errors.New("not configured")
+)
+
+// Error is returned by scrapers when scraping a source fails.
+type Error struct {
+	Source string // source name, e.g. "Indeed"
+	Kind   error  // one of the sentinel errors above
+	Detail string // optional context, e.g. the URL or selector involved
+	Err    error  // underlying cause, may be nil
+}
+
+// NewError creates a scraping error for the given source.
+func NewError(source string, kind error, detail string, err error) *Error {
+	return &Error{Source: source, Kind: kind, Detail: detail, Err: err}
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("%s: %v", e.Source, e.Kind)
+	if e.Detail != "" {
+		msg += " (" + e.Detail + ")"
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Is reports whether the error is of the given kind.
+func (e *Error) Is(target error) bool {
+	return e.Kind == target
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// launchError wraps an error returned while acquiring a browser page.
+// Cancellation is returned unchanged so callers can still detect it.
+func launchError(source string, err error) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	return NewError(source, ErrLaunch, "", err)
+}
@@ -0,0 +1,33 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestErrorIsKind tests that scraping errors match their kind and wrap their cause
+func TestErrorIsKind(t *testing.T) {
+	cause := errors.New("no such element")
+	err := error(NewError("Indeed", ErrSelectorNotFound, "h2", cause))
+
+	assert.ErrorIs(t, err, ErrSelectorNotFound)
+	assert.ErrorIs(t, err, cause)
+	assert.NotErrorIs(t, err, ErrBlocked)
+	assert.Equal(t, "Indeed: selector not found (h2): no such element", err.Error())
+}
+
+// TestErrorWithoutDetail tests the message of an error without detail or cause
+func TestErrorWithoutDetail(t *testing.T) {
+	err := NewError("LinkedIn", ErrEmptyPage, "", nil)
+
+	assert.Equal(t, "LinkedIn: empty page", err.Error())
+}
+
+// TestLaunchErrorKeepsCancellation tests that cancellation is not reported as a launch failure
+func TestLaunchErrorKeepsCancellation(t *testing.T) {
+	assert.Equal(t, context.Canceled, launchError("Indeed", context.Canceled))
+	assert.ErrorIs(t, launchError("Indeed", errors.New("exec: chrome not found")), ErrLaunch)
+}
@@ -0,0 +1,188 @@
+package scraper
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// FeedItem is an item of an RSS feed or an entry of an Atom feed.
+type FeedItem struct {
+	Title       string
+	Link        string
+	GUID        string
+	Description string // HTML
+	Author      string
+	Categories  []string
+	Published   time.Time
+
+	// Extra holds the text of the other elements of the item by name, e.g. the
+	// "region" and "type" elements We Work Remotely adds to its feed.
+	Extra map[string]string
+}
+
+// feedDateLayouts are the forms dates are found in: RFC 822 for RSS, RFC 3339 for Atom,
+// and their common variations.
+var feedDateLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	time.DateOnly,
+}
+
+type feedDoc struct {
+	XMLName xml.Name
+	Items   []rssItem   `xml:"channel>item"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type rssItem struct {
+	Title       string      `xml:"title"`
+	Link        string      `xml:"link"`
+	GUID        string      `xml:"guid"`
+	Description string      `xml:"description"`
+	Encoded     string      `xml:"encoded"` // content:encoded, the full text some feeds add
+	Author      string      `xml:"author"`
+	Creator     string      `xml:"creator"` // dc:creator
+	Categories  []string    `xml:"category"`
+	PubDate     string      `xml:"pubDate"`
+	Date        string      `xml:"date"` // dc:date
+	Other       []feedExtra `xml:",any"`
+}
+
+type atomEntry struct {
+	Title string `xml:"title"`
+	ID    string `xml:"id"`
+	Links []struct {
+		Href string `xml:"href,attr"`
+		Rel  string `xml:"rel,attr"`
+	} `xml:"link"`
+	Summary    string `xml:"summary"`
+	Content    string `xml:"content"`
+	Author     string `xml:"author>name"`
+	Categories []struct {
+		Term  string `xml:"term,attr"`
+		Label string `xml:"label,attr"`
+	} `xml:"category"`
+	Published string      `xml:"published"`
+	Updated   string      `xml:"updated"`
+	Other     []feedExtra `xml:",any"`
+}
+
+type feedExtra struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+// ParseFeed reads the items of an RSS 2.0 or Atom feed. Anything else is reported
+// as an error.
+func ParseFeed(body []byte) ([]FeedItem, error) {
+	var doc feedDoc
+	if err := xml.Unmarshal(body, &doc); err != nil {
+		return nil, err
+	}
+
+	switch doc.XMLName.Local {
+	case "rss":
+	case "feed":
+		return atomItems(doc.Entries), nil
+	default:
+		return nil, fmt.Errorf("unexpected <%s> element, not a feed", doc.XMLName.Local)
+	}
+
+	items := make([]FeedItem, 0, len(doc.Items))
+	for _, it := range doc.Items {
+		item := FeedItem{
+			Title:       strings.TrimSpace(it.Title),
+			Link:        strings.TrimSpace(it.Link),
+			GUID:        strings.TrimSpace(it.GUID),
+			Description: firstNonEmpty(it.Encoded, it.Description),
+			Author:      strings.TrimSpace(firstNonEmpty(it.Creator, it.Author)),
+			Categories:  trimAll(it.Categories),
+			Published:   feedDate(firstNonEmpty(it.PubDate, it.Date)),
+			Extra:       extra(it.Other),
+		}
+		if item.Link == "" {
+			item.Link = item.GUID
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func atomItems(entries []atomEntry) []FeedItem {
+	items := make([]FeedItem, 0, len(entries))
+	for _, e := range entries {
+		item := FeedItem{
+			Title:       strings.TrimSpace(e.Title),
+			GUID:        strings.TrimSpace(e.ID),
+			Description: firstNonEmpty(e.Content, e.Summary),
+			Author:      strings.TrimSpace(e.Author),
+			Published:   feedDate(firstNonEmpty(e.Published, e.Updated)),
+			Extra:       extra(e.Other),
+		}
+
+		for _, l := range e.Links {
+			if l.Rel == "" || l.Rel == "alternate" {
+				item.Link = strings.TrimSpace(l.Href)
+				break
+			}
+		}
+		for _, c := range e.Categories {
+			if term := strings.TrimSpace(firstNonEmpty(c.Label, c.Term)); term != "" {
+				item.Categories = append(item.Categories, term)
+			}
+		}
+
+		items = append(items, item)
+	}
+	return items
+}
+
+// feedDate parses a feed date, returning the zero time when it can't be.
+func feedDate(s string) time.Time {
+	s = strings.TrimSpace(s)
+	for _, layout := range feedDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC()
+		}
+	}
+	return time.Time{}
+}
+
+func extra(elems []feedExtra) map[string]string {
+	if len(elems) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(elems))
+	for _, e := range elems {
+		if v := strings.TrimSpace(e.Value); v != "" {
+			m[e.XMLName.Local] = v
+		}
+	}
+	return m
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func trimAll(values []string) []string {
+	var out []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
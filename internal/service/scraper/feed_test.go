@@ -0,0 +1,68 @@
+package scraper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseFeedRSS tests reading RSS items along with the elements a feed adds
+func TestParseFeedRSS(t *testing.T) {
+	items, err := ParseFeed([]byte(`<?xml version="1.0"?>
+<rss version="2.0" xmlns:dc="http://purl.org/dc/elements/1.1/">
+  <channel>
+    <title>Jobs</title>
+    <item>
+      <title>Acme: Go Developer</title>
+      <guid>https://example.com/jobs/1</guid>
+      <description><![CDATA[<p>Write Go</p>]]></description>
+      <dc:creator>Acme</dc:creator>
+      <category>Programming</category>
+      <category> Backend </category>
+      <pubDate>Mon, 06 May 2024 12:30:00 +0000</pubDate>
+      <region>Anywhere in the World</region>
+    </item>
+  </channel>
+</rss>`))
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+
+	assert.Equal(t, FeedItem{
+		Title:       "Acme: Go Developer",
+		Link:        "https://example.com/jobs/1",
+		GUID:        "https://example.com/jobs/1",
+		Description: "<p>Write Go</p>",
+		Author:      "Acme",
+		Categories:  []string{"Programming", "Backend"},
+		Published:   time.Date(2024, 5, 6, 12, 30, 0, 0, time.UTC),
+		Extra:       map[string]string{"region": "Anywhere in the World"},
+	}, items[0])
+}
+
+// TestParseFeedAtom tests reading Atom entries, and rejecting documents that aren't feeds
+func TestParseFeedAtom(t *testing.T) {
+	items, err := ParseFeed([]byte(`<feed xmlns="http://www.w3.org/2005/Atom">
+  <entry>
+    <title>Rust Engineer at Foo</title>
+    <id>tag:example.com,2024:42</id>
+    <link rel="self" href="https://example.com/feed/42"/>
+    <link href="https://example.com/jobs/42"/>
+    <summary>Systems work</summary>
+    <author><name>Foo</name></author>
+    <category term="rust"/>
+    <updated>2024-05-07T08:00:00+02:00</updated>
+  </entry>
+</feed>`))
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+
+	assert.Equal(t, "https://example.com/jobs/42", items[0].Link)
+	assert.Equal(t, "Systems work", items[0].Description)
+	assert.Equal(t, []string{"rust"}, items[0].Categories)
+	assert.Equal(t, time.Date(2024, 5, 7, 6, 0, 0, 0, time.UTC), items[0].Published)
+
+	_, err = ParseFeed([]byte(`<html><body>Not a feed</body></html>`))
+	assert.ErrorContains(t, err, "not a feed")
+}
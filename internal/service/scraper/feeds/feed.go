@@ -0,0 +1,100 @@
+package feeds
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/brandoyts/job-aggr/internal/config"
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+)
+
+// Feed is a feed along with the rules mapping its items to jobs.
+type Feed struct {
+	URL          string
+	Name         string         // source of its jobs, sourceName when empty
+	TitlePattern *regexp.Regexp // splits titles, may be nil
+	Company      string
+	Location     string
+	Categories   string // "tags", "department" or "ignore"
+}
+
+// NewFeed compiles the mapping rules of a feed from the config file.
+func NewFeed(cfg config.Feed) (Feed, error) {
+	f := Feed{
+		URL:        cfg.URL,
+		Name:       cfg.Name,
+		Company:    cfg.Company,
+		Location:   cfg.Location,
+		Categories: cfg.Categories,
+	}
+
+	if cfg.TitlePattern != "" {
+		re, err := regexp.Compile(cfg.TitlePattern)
+		if err != nil {
+			return f, fmt.Errorf("%s: title_pattern: %w", cfg.URL, err)
+		}
+		f.TitlePattern = re
+	}
+
+	return f, nil
+}
+
+// toJob maps an item of the feed.
+func (f Feed) toJob(item scraper.FeedItem) model.Job {
+	job := model.Job{
+		ID:          item.GUID,
+		Title:       item.Title,
+		Company:     f.Company,
+		Url:         item.Link,
+		Source:      f.Name,
+		Description: scraper.HTMLText(item.Description),
+		PostedAt:    item.Published,
+	}
+	if job.Source == "" {
+		job.Source = sourceName
+	}
+
+	if f.TitlePattern != nil {
+		if m := f.TitlePattern.FindStringSubmatch(item.Title); m != nil {
+			if v := group(f.TitlePattern, m, "title"); v != "" {
+				job.Title = v
+			}
+			if v := group(f.TitlePattern, m, "company"); v != "" {
+				job.Company = v
+			}
+			job.Location = group(f.TitlePattern, m, "location")
+		}
+	}
+
+	if job.Company == "" {
+		job.Company = item.Author
+	}
+	if job.Location == "" {
+		job.Location = f.Location
+	}
+	if strings.Contains(strings.ToLower(job.Location), "remote") {
+		job.Workplace = model.WorkplaceRemote
+	}
+
+	switch f.Categories {
+	case "department":
+		job.Department = strings.Join(item.Categories, ", ")
+	case "ignore":
+	default:
+		job.Tags = item.Categories
+	}
+
+	return job
+}
+
+// group returns the trimmed text matched by a named group, or "" when the pattern
+// doesn't have it or it didn't match.
+func group(re *regexp.Regexp, match []string, name string) string {
+	i := re.SubexpIndex(name)
+	if i < 0 {
+		return ""
+	}
+	return strings.TrimSpace(match[i])
+}
@@ -0,0 +1,113 @@
+package feeds
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+)
+
+const sourceName = "Feeds"
+
+// Fetch reads every feed and keeps the items matching the criteria. A feed that
+// cannot be read doesn't stop the others: the items of those that could be are
+// returned along with the error of every feed that couldn't.
+func Fetch(ctx context.Context, client *http.Client, feeds []Feed, paging scraper.Paging, criteria model.SearchCriteria) ([]model.Job, error) {
+	if len(feeds) == 0 {
+		return nil, scraper.NewError(sourceName, scraper.ErrNotConfigured, "no feeds", nil)
+	}
+
+	collector := scraper.NewCollector(paging, jobKey)
+	now := time.Now()
+
+	var errs []error
+	for _, feed := range feeds {
+		jobs, err := fetchFeed(ctx, client, feed)
+		if err != nil {
+			if ctx.Err() != nil {
+				return collector.Jobs(), err
+			}
+			errs = append(errs, err)
+			continue
+		}
+
+		var matching []model.Job
+		for _, job := range jobs {
+			if matches(criteria, job, now) {
+				matching = append(matching, job)
+			}
+		}
+		collector.Add(matching)
+
+		if collector.Full() {
+			break
+		}
+	}
+
+	return collector.Jobs(), errors.Join(errs...)
+}
+
+func fetchFeed(ctx context.Context, client *http.Client, feed Feed) ([]model.Job, error) {
+	name := feed.Name
+	if name == "" {
+		name = sourceName
+	}
+
+	body, err := scraper.Get(ctx, client, name, feed.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := scraper.ParseFeed(body)
+	if err != nil {
+		return nil, scraper.NewError(name, scraper.ErrInvalidResponse, feed.URL, err)
+	}
+
+	jobs := make([]model.Job, 0, len(items))
+	for _, item := range items {
+		jobs = append(jobs, feed.toJob(item))
+	}
+	return jobs, nil
+}
+
+// matches filters the feeds client-side, since they can't be searched.
+func matches(criteria model.SearchCriteria, job model.Job, now time.Time) bool {
+	remote := job.Workplace == model.WorkplaceRemote
+
+	if criteria.Workplace == model.WorkplaceRemote && !remote {
+		return false
+	}
+
+	return scraper.MatchQuery(criteria.Query, job.Title, job.Department, strings.Join(job.Tags, " ")) &&
+		scraper.MatchLocation(criteria.Location, job.Location, remote) &&
+		scraper.MatchPosted(criteria.PostedWithin, job.PostedAt, now)
+}
+
+// unsupported lists the criteria matches ignores. Only remote jobs can be told apart.
+func unsupported(c model.SearchCriteria) []string {
+	var names []string
+	for _, name := range c.Criteria() {
+		switch name {
+		case model.CriterionPostedWithin:
+		case model.CriterionWorkplace:
+			if c.Workplace != model.WorkplaceRemote {
+				names = append(names, name)
+			}
+		default:
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func jobKey(job model.Job) string {
+	key := job.ID
+	if key == "" {
+		key = job.Url
+	}
+	return job.Source + "\x00" + key
+}
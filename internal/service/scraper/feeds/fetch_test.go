@@ -0,0 +1,128 @@
+package feeds
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/config"
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+	"github.com/brandoyts/job-aggr/internal/service/scraper/scrapertest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newServer serves the fixture feeds
+func newServer(t *testing.T) *httptest.Server {
+	return scrapertest.NewServer(t, map[string]string{
+		"/board.rss":    "testdata/board.rss",
+		"/company.atom": "testdata/company.atom",
+	}, nil)
+}
+
+// newScraper creates the source as the config file describes it
+func newScraper(t *testing.T, srv *httptest.Server) *scraper.Scraper {
+	cfg, err := config.Parse([]byte(fmt.Sprintf(`
+sources:
+  feeds:
+    feeds:
+      - url: %[1]s/board.rss
+        name: Gopher Jobs
+        title_pattern: '^(?P<company>.+?) is hiring an? (?P<title>.+) in (?P<location>.+)$'
+      - url: %[1]s/company.atom
+        company: Foo Labs
+        location: Lisbon, Portugal
+        categories: department
+`, srv.URL)))
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate([]string{"feeds"}))
+
+	s, err := scraper.New("feeds", nil, cfg.Source("feeds"))
+	require.NoError(t, err)
+	return s.(*scraper.Scraper)
+}
+
+// TestFetchMapsItems tests that items are mapped with the rules of their feed
+func TestFetchMapsItems(t *testing.T) {
+	s := newScraper(t, newServer(t))
+
+	jobs, err := s.Fetch(context.Background(), model.SearchCriteria{})
+	require.NoError(t, err)
+	require.Len(t, jobs, 4)
+
+	assert.Equal(t, model.Job{
+		ID:          "gopherjobs-1",
+		Title:       "Senior Go Developer",
+		Company:     "Acme",
+		Location:    "Berlin",
+		Url:         "https://gopherjobs.example/jobs/1",
+		Source:      "Gopher Jobs",
+		Description: "Payments in Go.",
+		Tags:        []string{"golang", "backend"},
+		PostedAt:    time.Date(2024, 5, 7, 9, 15, 0, 0, time.UTC),
+	}, jobs[0])
+
+	assert.Equal(t, model.WorkplaceRemote, jobs[1].Workplace)
+	assert.Equal(t, "Newsletter: May edition", jobs[2].Title, "titles the pattern doesn't match are kept whole")
+	assert.Empty(t, jobs[2].Company)
+
+	assert.Equal(t, model.Job{
+		ID:          "urn:foolabs:job:77",
+		Title:       "Staff Rust Engineer",
+		Company:     "Foo Labs",
+		Location:    "Lisbon, Portugal",
+		Url:         "https://foolabs.example/careers/77",
+		Source:      "Feeds",
+		Description: "Compilers and runtimes.",
+		Department:  "Engineering",
+		PostedAt:    time.Date(2024, 5, 2, 12, 0, 0, 0, time.UTC),
+	}, jobs[3])
+}
+
+// TestFetchFilters tests that items are filtered by the search criteria
+func TestFetchFilters(t *testing.T) {
+	s := newScraper(t, newServer(t))
+
+	tests := []struct {
+		name     string
+		criteria model.SearchCriteria
+		want     []string
+	}{
+		{"query matches tags", model.SearchCriteria{Query: "golang"}, []string{"Senior Go Developer"}},
+		{"query matches department", model.SearchCriteria{Query: "engineering"}, []string{"Staff Rust Engineer"}},
+		{"location", model.SearchCriteria{Location: "Lisbon"}, []string{"Staff Rust Engineer"}},
+		{"remote", model.SearchCriteria{Workplace: model.WorkplaceRemote}, []string{"Platform Engineer"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobs, err := s.Fetch(context.Background(), tt.criteria)
+			require.NoError(t, err)
+
+			var titles []string
+			for _, job := range jobs {
+				titles = append(titles, job.Title)
+			}
+			assert.Equal(t, tt.want, titles)
+		})
+	}
+}
+
+// TestFetchFeedErrors tests that a failing feed is reported along with the items of the others
+func TestFetchFeedErrors(t *testing.T) {
+	srv := newServer(t)
+	board := Feed{URL: srv.URL + "/board.rss"}
+	missing := Feed{URL: srv.URL + "/missing.rss", Name: "Missing"}
+
+	jobs, err := Fetch(context.Background(), nil, []Feed{missing, board}, scraper.Paging{}, model.SearchCriteria{})
+	assert.ErrorIs(t, err, scraper.ErrNavigation)
+	assert.ErrorContains(t, err, "Missing")
+	assert.Len(t, jobs, 3)
+
+	_, err = Fetch(context.Background(), nil, []Feed{missing}, scraper.Paging{}, model.SearchCriteria{})
+	assert.ErrorIs(t, err, scraper.ErrNavigation)
+	assert.ErrorContains(t, err, "Missing")
+}
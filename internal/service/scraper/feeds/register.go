@@ -0,0 +1,30 @@
+package feeds
+
+import (
+	"github.com/brandoyts/job-aggr/internal/config"
+	"github.com/brandoyts/job-aggr/internal/service/aggregator"
+	"github.com/brandoyts/job-aggr/internal/service/browser"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+)
+
+func init() {
+	scraper.Register(scraper.Source{
+		Name:         "feeds",
+		Label:        sourceName,
+		Capabilities: scraper.Capabilities{RemoteFilter: true},
+		New: func(_ *browser.Pool, cfg config.Source) (aggregator.JobScraper, error) {
+			feeds := make([]Feed, 0, len(cfg.Feeds))
+			for _, fc := range cfg.Feeds {
+				f, err := NewFeed(fc)
+				if err != nil {
+					return nil, err
+				}
+				feeds = append(feeds, f)
+			}
+			return NewScraper(feeds, scraper.ConfigOptions(cfg)...), nil
+		},
+		Configured: func(cfg config.Source) bool {
+			return len(cfg.Feeds) > 0
+		},
+	})
+}
@@ -0,0 +1,21 @@
+// Package feeds reads job openings published as RSS or Atom feeds, mapping their
+// items to jobs with rules from the config file so boards can be added without code.
+package feeds
+
+import (
+	"context"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+)
+
+// NewScraper creates a scraper reading the given feeds.
+func NewScraper(feeds []Feed, opts ...scraper.Option) *scraper.Scraper {
+	return scraper.NewScraper(scraper.Definition{
+		Name: sourceName,
+		Fetch: func(ctx context.Context, s scraper.Settings, criteria model.SearchCriteria) ([]model.Job, error) {
+			return Fetch(ctx, s.Client, feeds, s.Paging, criteria)
+		},
+		Unsupported: unsupported,
+	}, opts...)
+}
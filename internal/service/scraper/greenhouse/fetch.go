@@ -0,0 +1,130 @@
+package greenhouse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+)
+
+// DefaultBaseURL is Greenhouse's public job board API.
+const DefaultBaseURL = "https://boards-api.greenhouse.io"
+
+const (
+	sourceName = "Greenhouse"
+
+	// boardPath lists every job of a board, with its description.
+	boardPath = "/v1/boards/%s/jobs?content=true"
+)
+
+// board is the response of the jobs endpoint.
+type board struct {
+	Jobs []posting `json:"jobs"`
+}
+
+type posting struct {
+	ID             int64  `json:"id"`
+	Title          string `json:"title"`
+	CompanyName    string `json:"company_name"`
+	AbsoluteURL    string `json:"absolute_url"`
+	Content        string `json:"content"` // HTML, escaped once more
+	UpdatedAt      string `json:"updated_at"`
+	FirstPublished string `json:"first_published"`
+	Location       struct {
+		Name string `json:"name"`
+	} `json:"location"`
+	Departments []struct {
+		Name string `json:"name"`
+	} `json:"departments"`
+}
+
+// Fetch reads every board and keeps the jobs matching the criteria.
+func Fetch(ctx context.Context, client *http.Client, baseURL string, boards []string, paging scraper.Paging, criteria model.SearchCriteria) ([]model.Job, error) {
+	now := time.Now()
+
+	return scraper.FetchCompanies(ctx, sourceName, boards, paging, jobKey, func(ctx context.Context, token string) ([]model.Job, error) {
+		var b board
+		if err := scraper.GetJSON(ctx, client, sourceName, baseURL+fmt.Sprintf(boardPath, url.PathEscape(token)), &b); err != nil {
+			return nil, err
+		}
+
+		var jobs []model.Job
+		for _, p := range b.Jobs {
+			job := toJob(token, p)
+			if matches(criteria, job, now) {
+				jobs = append(jobs, job)
+			}
+		}
+		return jobs, nil
+	})
+}
+
+func toJob(token string, p posting) model.Job {
+	company := p.CompanyName
+	if company == "" {
+		company = token
+	}
+
+	job := model.Job{
+		ID:          strconv.FormatInt(p.ID, 10),
+		Title:       p.Title,
+		Company:     company,
+		Location:    p.Location.Name,
+		Url:         p.AbsoluteURL,
+		Source:      sourceName,
+		Description: scraper.HTMLText(p.Content),
+	}
+
+	if len(p.Departments) > 0 {
+		job.Department = p.Departments[0].Name
+	}
+
+	// first_published is missing from older boards; updated_at is the best we have then.
+	for _, t := range []string{p.FirstPublished, p.UpdatedAt} {
+		if posted, err := time.Parse(time.RFC3339, t); err == nil {
+			job.PostedAt = posted
+			break
+		}
+	}
+
+	return job
+}
+
+// matches filters the board client-side, since the API returns every job of a board.
+func matches(criteria model.SearchCriteria, job model.Job, now time.Time) bool {
+	remote := scraper.MatchQuery("remote", job.Location)
+
+	if criteria.Workplace == model.WorkplaceRemote && !remote {
+		return false
+	}
+
+	return scraper.MatchQuery(criteria.Query, job.Title, job.Department) &&
+		scraper.MatchLocation(criteria.Location, job.Location, remote) &&
+		scraper.MatchPosted(criteria.PostedWithin, job.PostedAt, now)
+}
+
+// unsupported lists the criteria matches ignores.
+func unsupported(c model.SearchCriteria) []string {
+	var names []string
+	for _, name := range c.Criteria() {
+		switch name {
+		case model.CriterionPostedWithin:
+		case model.CriterionWorkplace:
+			if c.Workplace != model.WorkplaceRemote {
+				names = append(names, name)
+			}
+		default:
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func jobKey(job model.Job) string {
+	return job.ID
+}
@@ -0,0 +1,98 @@
+package greenhouse
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+	"github.com/brandoyts/job-aggr/internal/service/scraper/scrapertest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newServer serves the board fixture for the "acme" board and fails every other one
+func newServer(t *testing.T) *httptest.Server {
+	return scrapertest.NewServer(t, map[string]string{"/v1/boards/acme/jobs": "testdata/board.json"}, func(r *http.Request) {
+		assert.Equal(t, "true", r.URL.Query().Get("content"))
+	})
+}
+
+// TestFetchMapsJobs tests that every field of a posting is mapped
+func TestFetchMapsJobs(t *testing.T) {
+	srv := newServer(t)
+
+	jobs, err := Fetch(context.Background(), nil, srv.URL, []string{"acme"}, scraper.Paging{}, model.SearchCriteria{})
+	require.NoError(t, err)
+	require.Len(t, jobs, 3)
+
+	assert.Equal(t, model.Job{
+		ID:          "4012345",
+		Title:       "Senior Backend Engineer (Go)",
+		Company:     "Acme",
+		Location:    "Berlin, Germany",
+		Url:         "https://boards.greenhouse.io/acme/jobs/4012345",
+		Source:      "Greenhouse",
+		Description: "About the role\nYou will build APIs in Go.",
+		Department:  "Engineering",
+		PostedAt:    time.Date(2024, 5, 8, 13, 30, 0, 0, time.UTC),
+	}, withUTC(jobs[0]))
+
+	assert.Equal(t, time.Date(2024, 4, 1, 8, 0, 0, 0, time.UTC), jobs[1].PostedAt, "updated_at is used without first_published")
+	assert.Equal(t, "acme", jobs[2].Company, "the board token names companies without a name")
+	assert.Empty(t, jobs[2].Department)
+}
+
+// TestFetchFilters tests that boards are filtered by the search criteria
+func TestFetchFilters(t *testing.T) {
+	srv := newServer(t)
+
+	tests := []struct {
+		name     string
+		criteria model.SearchCriteria
+		want     []string
+	}{
+		{"query matches title", model.SearchCriteria{Query: "backend go"}, []string{"4012345"}},
+		{"query matches department", model.SearchCriteria{Query: "engineering"}, []string{"4012345", "4012346"}},
+		{"location", model.SearchCriteria{Location: "New York"}, []string{"4012347"}},
+		{"remote location", model.SearchCriteria{Location: "Remote"}, []string{"4012346"}},
+		{"remote workplace", model.SearchCriteria{Workplace: model.WorkplaceRemote}, []string{"4012346"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobs, err := Fetch(context.Background(), nil, srv.URL, []string{"acme"}, scraper.Paging{}, tt.criteria)
+			require.NoError(t, err)
+
+			var ids []string
+			for _, job := range jobs {
+				ids = append(ids, job.ID)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
+
+// TestFetchBoardErrors tests that a failing board is reported along with the jobs of the others
+func TestFetchBoardErrors(t *testing.T) {
+	srv := newServer(t)
+
+	jobs, err := Fetch(context.Background(), nil, srv.URL, []string{"missing", "acme"}, scraper.Paging{MaxResults: 2}, model.SearchCriteria{})
+	assert.ErrorIs(t, err, scraper.ErrNavigation)
+	assert.ErrorContains(t, err, "/boards/missing/")
+	assert.Len(t, jobs, 2)
+
+	_, err = Fetch(context.Background(), nil, srv.URL, []string{"missing"}, scraper.Paging{}, model.SearchCriteria{})
+	assert.ErrorIs(t, err, scraper.ErrNavigation)
+
+	_, err = Fetch(context.Background(), nil, srv.URL, nil, scraper.Paging{}, model.SearchCriteria{})
+	assert.ErrorIs(t, err, scraper.ErrNotConfigured)
+}
+
+func withUTC(job model.Job) model.Job {
+	job.PostedAt = job.PostedAt.UTC()
+	return job
+}
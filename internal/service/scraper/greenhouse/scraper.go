@@ -0,0 +1,23 @@
+// Package greenhouse reads the job boards companies host on Greenhouse through
+// its public boards API, without a browser.
+package greenhouse
+
+import (
+	"context"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+)
+
+// NewScraper creates a scraper reading the given boards, identified by their board
+// token: the "acme" of boards.greenhouse.io/acme.
+func NewScraper(boards []string, opts ...scraper.Option) *scraper.Scraper {
+	return scraper.NewScraper(scraper.Definition{
+		Name: sourceName,
+		Fetch: func(ctx context.Context, s scraper.Settings, criteria model.SearchCriteria) ([]model.Job, error) {
+			return Fetch(ctx, s.Client, s.BaseURL, boards, s.Paging, criteria)
+		},
+		Unsupported: unsupported,
+		Defaults:    scraper.Settings{BaseURL: DefaultBaseURL},
+	}, opts...)
+}
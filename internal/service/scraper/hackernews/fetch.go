@@ -0,0 +1,176 @@
+package hackernews
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+)
+
+// DefaultBaseURL is the Algolia search API of Hacker News.
+const DefaultBaseURL = "https://hn.algolia.com"
+
+const (
+	sourceName = "Hacker News"
+
+	// threadsPath lists the latest stories of the account posting the monthly threads.
+	threadsPath = "/api/v1/search_by_date?tags=story,author_whoishiring&hitsPerPage=20"
+	// itemPath returns a story along with its whole comment tree.
+	itemPath = "/api/v1/items/%d"
+
+	// threadTitle starts the title of the monthly threads, e.g. "Ask HN: Who is hiring? (May 2024)".
+	threadTitle = "Ask HN: Who is hiring?"
+
+	commentURL = "https://news.ycombinator.com/item?id=%d"
+)
+
+type search struct {
+	Hits []struct {
+		ObjectID string `json:"objectID"`
+		Title    string `json:"title"`
+	} `json:"hits"`
+}
+
+// item is a story or comment as returned by the items endpoint. Deleted comments have no author.
+type item struct {
+	ID        int64     `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	Author    string    `json:"author"`
+	Title     string    `json:"title"`
+	Text      string    `json:"text"` // HTML
+	Children  []item    `json:"children"`
+}
+
+// Fetch reads the given threads, or the latest one when none is given, and keeps
+// the job posts matching the criteria. A thread that cannot be read doesn't stop
+// the others: their posts are returned along with its error.
+func Fetch(ctx context.Context, client *http.Client, baseURL string, threads []int64, paging scraper.Paging, criteria model.SearchCriteria) ([]model.Job, error) {
+	if len(threads) == 0 {
+		latest, err := latestThread(ctx, client, baseURL)
+		if err != nil {
+			return nil, err
+		}
+		threads = []int64{latest}
+	}
+
+	collector := scraper.NewCollector(paging, jobKey)
+	now := time.Now()
+
+	var errs []error
+	for _, id := range threads {
+		var thread item
+		if err := scraper.GetJSON(ctx, client, sourceName, baseURL+fmt.Sprintf(itemPath, id), &thread); err != nil {
+			if ctx.Err() != nil {
+				return collector.Jobs(), err
+			}
+			errs = append(errs, err)
+			continue
+		}
+
+		var jobs []model.Job
+		for _, comment := range thread.Children {
+			job, ok := toJob(comment)
+			if ok && matches(criteria, job, now) {
+				jobs = append(jobs, job)
+			}
+		}
+		collector.Add(jobs)
+
+		if collector.Full() {
+			break
+		}
+	}
+
+	return collector.Jobs(), errors.Join(errs...)
+}
+
+// latestThread finds the most recent "Who is hiring?" thread.
+func latestThread(ctx context.Context, client *http.Client, baseURL string) (int64, error) {
+	var s search
+	if err := scraper.GetJSON(ctx, client, sourceName, baseURL+threadsPath, &s); err != nil {
+		return 0, err
+	}
+
+	for _, hit := range s.Hits {
+		if strings.HasPrefix(hit.Title, threadTitle) {
+			return strconv.ParseInt(hit.ObjectID, 10, 64)
+		}
+	}
+
+	return 0, scraper.NewError(sourceName, scraper.ErrEmptyPage, "no \"Who is hiring?\" thread", nil)
+}
+
+// ThreadID returns the ID of a thread given as a URL, e.g. "https://news.ycombinator.com/item?id=40224213",
+// or as the bare ID.
+func ThreadID(thread string) (int64, error) {
+	if u, err := url.Parse(thread); err == nil && u.Query().Has("id") {
+		thread = u.Query().Get("id")
+	}
+	return strconv.ParseInt(strings.TrimSpace(thread), 10, 64)
+}
+
+// toJob turns a top-level comment into a job. It reports false for comments that
+// aren't job posts, or were deleted.
+func toJob(comment item) (model.Job, bool) {
+	if comment.Author == "" {
+		return model.Job{}, false
+	}
+
+	text := scraper.HTMLText(comment.Text)
+	first, _, _ := strings.Cut(text, "\n")
+
+	h, ok := parseHeader(first)
+	if !ok {
+		return model.Job{}, false
+	}
+
+	return model.Job{
+		ID:             strconv.FormatInt(comment.ID, 10),
+		Title:          h.Role,
+		Company:        h.Company,
+		Location:       h.Location,
+		Url:            fmt.Sprintf(commentURL, comment.ID),
+		Source:         sourceName,
+		Salary:         h.Salary,
+		Description:    text,
+		EmploymentType: h.EmploymentType,
+		Workplace:      h.Workplace,
+		PostedAt:       comment.CreatedAt.UTC(),
+	}, true
+}
+
+// matches filters the comments client-side. The query is matched against the whole
+// comment, since the header line rarely names the technologies used.
+func matches(criteria model.SearchCriteria, job model.Job, now time.Time) bool {
+	remote := job.Workplace == model.WorkplaceRemote
+
+	if criteria.Workplace != "" && job.Workplace != "" && job.Workplace != criteria.Workplace {
+		return false
+	}
+
+	return scraper.MatchQuery(criteria.Query, job.Description) &&
+		scraper.MatchLocation(criteria.Location, job.Location, remote) &&
+		scraper.MatchPosted(criteria.PostedWithin, job.PostedAt, now)
+}
+
+// unsupported lists the criteria matches ignores.
+func unsupported(c model.SearchCriteria) []string {
+	var names []string
+	for _, name := range c.Criteria() {
+		if name != model.CriterionWorkplace && name != model.CriterionPostedWithin {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func jobKey(job model.Job) string {
+	return job.ID
+}
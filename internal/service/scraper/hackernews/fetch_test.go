@@ -0,0 +1,97 @@
+package hackernews
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+	"github.com/brandoyts/job-aggr/internal/service/scraper/scrapertest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newServer serves the search and thread fixtures like the Algolia API
+func newServer(t *testing.T) *httptest.Server {
+	return scrapertest.NewServer(t, map[string]string{
+		"/api/v1/search_by_date": "testdata/search.json",
+		"/api/v1/items/40224213": "testdata/thread.json",
+	}, nil)
+}
+
+// TestFetchLatestThread tests that the latest thread is found and its job posts mapped
+func TestFetchLatestThread(t *testing.T) {
+	srv := newServer(t)
+
+	jobs, err := Fetch(context.Background(), nil, srv.URL, nil, scraper.Paging{}, model.SearchCriteria{})
+	require.NoError(t, err)
+	require.Len(t, jobs, 3, "deleted comments, replies and other comments are skipped")
+
+	assert.Equal(t, model.Job{
+		ID:          "40224301",
+		Title:       "Senior Go Engineer",
+		Company:     "Acme",
+		Location:    "Berlin, Germany",
+		Url:         "https://news.ycombinator.com/item?id=40224301",
+		Source:      "Hacker News",
+		Salary:      "$150k-$180k",
+		Description: "Acme | Senior Go Engineer | Berlin, Germany | REMOTE | $150k-$180k\nWe build payment infrastructure in Go and Postgres.\nApply: https://acme.example/jobs",
+		Workplace:   model.WorkplaceRemote,
+		PostedAt:    time.Date(2024, 5, 1, 15, 2, 11, 0, time.UTC),
+	}, jobs[0])
+
+	assert.Equal(t, "Widgets Inc", jobs[1].Company)
+	assert.Equal(t, "Full-time", jobs[1].EmploymentType)
+	assert.Equal(t, "Foo Labs", jobs[2].Company)
+}
+
+// TestFetchFilters tests that comments are matched against the search criteria
+func TestFetchFilters(t *testing.T) {
+	srv := newServer(t)
+
+	tests := []struct {
+		name     string
+		criteria model.SearchCriteria
+		want     []string
+	}{
+		{"every query word must occur", model.SearchCriteria{Query: "golang postgres"}, nil},
+		{"query matches the body", model.SearchCriteria{Query: "go postgres"}, []string{"Acme"}},
+		{"query words anywhere", model.SearchCriteria{Query: "go typescript"}, []string{"Foo Labs"}},
+		{"location", model.SearchCriteria{Location: "NYC"}, []string{"Widgets Inc"}},
+		{"remote", model.SearchCriteria{Location: "Remote"}, []string{"Acme", "Foo Labs"}},
+		{"workplace", model.SearchCriteria{Workplace: model.WorkplaceOnSite}, []string{"Widgets Inc"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobs, err := Fetch(context.Background(), nil, srv.URL, []int64{40224213}, scraper.Paging{}, tt.criteria)
+			require.NoError(t, err)
+
+			var companies []string
+			for _, job := range jobs {
+				companies = append(companies, job.Company)
+			}
+			assert.Equal(t, tt.want, companies)
+		})
+	}
+}
+
+// TestFetchMissingThread tests that a thread that cannot be read fails the search
+func TestFetchMissingThread(t *testing.T) {
+	srv := newServer(t)
+
+	_, err := Fetch(context.Background(), nil, srv.URL, []int64{1}, scraper.Paging{}, model.SearchCriteria{})
+	assert.ErrorIs(t, err, scraper.ErrNavigation)
+}
+
+// TestThreadID tests reading thread IDs from URLs
+func TestThreadID(t *testing.T) {
+	id, err := ThreadID("https://news.ycombinator.com/item?id=40224213")
+	require.NoError(t, err)
+	assert.Equal(t, int64(40224213), id)
+
+	_, err = ThreadID("https://news.ycombinator.com/news")
+	assert.Error(t, err)
+}
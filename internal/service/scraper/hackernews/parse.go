@@ -0,0 +1,132 @@
+package hackernews
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+)
+
+var (
+	// salaryPattern recognises the salary field, e.g. "$150k-$180k" or "€70,000 + equity".
+	salaryPattern = regexp.MustCompile(`[$€£]\s?\d|\d+\s?[kK]\b|\b\d{2,3},\d{3}\b`)
+
+	// urlPattern recognises a link, often given after the company name.
+	urlPattern = regexp.MustCompile(`(?i)\(?\bhttps?://\S+\)?`)
+
+	// roleWords tell a role apart from a location when fields are out of order.
+	roleWords = regexp.MustCompile(`(?i)\b(engineers?|developers?|designers?|managers?|scientists?|architects?|analysts?|leads?|heads?|sre|devops|cto|founding|intern(ship)?s?|researchers?|programmers?)\b`)
+)
+
+var employmentTypes = []struct {
+	pattern *regexp.Regexp
+	name    string
+}{
+	{regexp.MustCompile(`(?i)\bfull[- ]?time\b`), "Full-time"},
+	{regexp.MustCompile(`(?i)\bpart[- ]?time\b`), "Part-time"},
+	{regexp.MustCompile(`(?i)\b(contract|contractor|freelance)\b`), "Contract"},
+	{regexp.MustCompile(`(?i)\binternships?\b`), "Internship"},
+}
+
+var workplaces = []struct {
+	pattern   *regexp.Regexp
+	workplace model.WorkplaceType
+}{
+	{regexp.MustCompile(`(?i)\bremote\b`), model.WorkplaceRemote},
+	{regexp.MustCompile(`(?i)\bhybrid\b`), model.WorkplaceHybrid},
+	{regexp.MustCompile(`(?i)\b(onsite|on-site|on site|in[- ]office)\b`), model.WorkplaceOnSite},
+}
+
+// header is what the first line of a "Who is hiring?" comment tells about the job.
+type header struct {
+	Company        string
+	Role           string
+	Location       string
+	Workplace      model.WorkplaceType
+	Salary         string
+	EmploymentType string
+}
+
+// parseHeader reads the conventional "Company | Role | Location | REMOTE | Salary"
+// first line of a comment. Only the company has to come first; the other fields
+// are recognised by their content. It reports false for lines without a "|",
+// which aren't job posts.
+func parseHeader(line string) (header, bool) {
+	fields := strings.Split(line, "|")
+	if len(fields) < 2 {
+		return header{}, false
+	}
+
+	h := header{Company: strings.TrimSpace(urlPattern.ReplaceAllString(fields[0], ""))}
+	if h.Company == "" {
+		return header{}, false
+	}
+
+	var rest []string
+	for _, field := range fields[1:] {
+		field = strings.TrimSpace(field)
+		if field == "" || urlPattern.MatchString(field) && strings.TrimSpace(urlPattern.ReplaceAllString(field, "")) == "" {
+			continue
+		}
+
+		classified := false
+		if h.Salary == "" && salaryPattern.MatchString(field) {
+			h.Salary = field
+			classified = true
+		}
+		if h.EmploymentType == "" && !roleWords.MatchString(field) {
+			for _, t := range employmentTypes {
+				if t.pattern.MatchString(field) {
+					h.EmploymentType = t.name
+					classified = true
+					break
+				}
+			}
+		}
+		for _, w := range workplaces {
+			if w.pattern.MatchString(field) {
+				if h.Workplace == "" {
+					h.Workplace = w.workplace
+				}
+				// "REMOTE" alone says nothing about the place, "Remote (EU)" does.
+				classified = classified || isWorkplaceOnly(field)
+				break
+			}
+		}
+
+		if !classified {
+			rest = append(rest, field)
+		}
+	}
+
+	// The role is the first field naming one, the location the first of the others.
+	for i, field := range rest {
+		if roleWords.MatchString(field) {
+			h.Role = field
+			rest = append(rest[:i:i], rest[i+1:]...)
+			break
+		}
+	}
+	if h.Role == "" && len(rest) > 0 {
+		h.Role, rest = rest[0], rest[1:]
+	}
+	if len(rest) > 0 {
+		h.Location = rest[0]
+	}
+
+	if h.Location == "" && h.Workplace == model.WorkplaceRemote {
+		h.Location = "Remote"
+	}
+
+	return h, true
+}
+
+// isWorkplaceOnly reports whether a field only names a workplace type, e.g. "REMOTE" or "Onsite".
+func isWorkplaceOnly(field string) bool {
+	rest := field
+	for _, w := range workplaces {
+		rest = w.pattern.ReplaceAllString(rest, "")
+	}
+	rest = strings.Trim(rest, " ,/()+&-")
+	return rest == "" || strings.EqualFold(rest, "or") || strings.EqualFold(rest, "ok")
+}
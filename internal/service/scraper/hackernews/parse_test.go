@@ -0,0 +1,48 @@
+package hackernews
+
+import (
+	"testing"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseHeader tests reading the first line of comments in the forms they are found in
+func TestParseHeader(t *testing.T) {
+	tests := []struct {
+		line string
+		want header
+		ok   bool
+	}{
+		{
+			line: "Acme | Senior Go Engineer | Berlin, Germany | REMOTE | $150k-$180k",
+			want: header{Company: "Acme", Role: "Senior Go Engineer", Location: "Berlin, Germany", Workplace: model.WorkplaceRemote, Salary: "$150k-$180k"},
+			ok:   true,
+		},
+		{
+			line: "Widgets Inc (https://widgets.example) | NYC | ONSITE | Full-time | Staff Backend Developer",
+			want: header{Company: "Widgets Inc", Role: "Staff Backend Developer", Location: "NYC", Workplace: model.WorkplaceOnSite, EmploymentType: "Full-time"},
+			ok:   true,
+		},
+		{
+			line: "Foo Labs | Founding Engineer | Remote (US only) | €90,000 + equity",
+			want: header{Company: "Foo Labs", Role: "Founding Engineer", Location: "Remote (US only)", Workplace: model.WorkplaceRemote, Salary: "€90,000 + equity"},
+			ok:   true,
+		},
+		{
+			line: "Bar Co | Data Scientist | REMOTE",
+			want: header{Company: "Bar Co", Role: "Data Scientist", Location: "Remote", Workplace: model.WorkplaceRemote},
+			ok:   true,
+		},
+		{line: "We are hiring lots of people, email me!", ok: false},
+		{line: " | Engineer", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got, ok := parseHeader(tt.line)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package hackernews
+
+import (
+	"fmt"
+
+	"github.com/brandoyts/job-aggr/internal/config"
+	"github.com/brandoyts/job-aggr/internal/service/aggregator"
+	"github.com/brandoyts/job-aggr/internal/service/browser"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+)
+
+func init() {
+	scraper.Register(scraper.Source{
+		Name:         "hackernews",
+		Label:        sourceName,
+		Capabilities: scraper.Capabilities{Salary: true, RemoteFilter: true},
+		New: func(_ *browser.Pool, cfg config.Source) (aggregator.JobScraper, error) {
+			threads, err := configThreads(cfg)
+			if err != nil {
+				return nil, err
+			}
+			return NewScraper(threads, scraper.ConfigOptions(cfg)...), nil
+		},
+		OptIn: true,
+	})
+}
+
+// configThreads returns the threads pinned by the urls of the source in the config
+// file, none to read the latest one.
+func configThreads(cfg config.Source) ([]int64, error) {
+	var threads []int64
+	for _, u := range cfg.URLs {
+		id, err := ThreadID(u)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %q is not a thread", sourceName, u)
+		}
+		threads = append(threads, id)
+	}
+	return threads, nil
+}
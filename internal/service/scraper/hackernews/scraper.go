@@ -0,0 +1,23 @@
+// Package hackernews reads the job posts of the monthly "Ask HN: Who is hiring?"
+// threads through the Hacker News search API, without a browser.
+package hackernews
+
+import (
+	"context"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+)
+
+// NewScraper creates a scraper reading the given threads, or the latest "Who is hiring?"
+// thread when there are none.
+func NewScraper(threads []int64, opts ...scraper.Option) *scraper.Scraper {
+	return scraper.NewScraper(scraper.Definition{
+		Name: sourceName,
+		Fetch: func(ctx context.Context, s scraper.Settings, criteria model.SearchCriteria) ([]model.Job, error) {
+			return Fetch(ctx, s.Client, s.BaseURL, threads, s.Paging, criteria)
+		},
+		Unsupported: unsupported,
+		Defaults:    scraper.Settings{BaseURL: DefaultBaseURL},
+	}, opts...)
+}
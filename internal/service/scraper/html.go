@@ -0,0 +1,104 @@
+package scraper
+
+import (
+	"html"
+	"strings"
+
+	xhtml "golang.org/x/net/html"
+)
+
+// blockTags start a new line in the text of an HTML fragment.
+var blockTags = map[string]bool{
+	"p": true, "div": true, "br": true, "li": true, "ul": true, "ol": true, "tr": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+}
+
+// HTMLText returns the text of an HTML fragment, such as a job description returned
+// by an API, with one line per paragraph or list item. Fragments that were escaped
+// once more, as some APIs do, are unescaped first.
+func HTMLText(fragment string) string {
+	if !strings.Contains(fragment, "<") && strings.Contains(fragment, "&lt;") {
+		fragment = html.UnescapeString(fragment)
+	}
+
+	var lines []string
+	var line strings.Builder
+
+	flush := func() {
+		if text := strings.Join(strings.Fields(line.String()), " "); text != "" {
+			lines = append(lines, text)
+		}
+		line.Reset()
+	}
+
+	skip := 0 // depth inside script and style elements
+
+	z := xhtml.NewTokenizer(strings.NewReader(fragment))
+	for {
+		tt := z.Next()
+		switch tt {
+		case xhtml.ErrorToken:
+			flush()
+			return strings.Join(lines, "\n")
+		case xhtml.TextToken:
+			if skip == 0 {
+				line.Write(z.Text())
+			}
+		case xhtml.StartTagToken, xhtml.EndTagToken, xhtml.SelfClosingTagToken:
+			name, _ := z.TagName()
+			switch string(name) {
+			case "script", "style":
+				if tt == xhtml.StartTagToken {
+					skip++
+				} else if tt == xhtml.EndTagToken && skip > 0 {
+					skip--
+				}
+			}
+			if blockTags[string(name)] {
+				flush()
+			}
+		}
+	}
+}
+
+// NodeText returns the text of a parsed HTML node with runs of whitespace
+// collapsed, leaving out scripts and styles.
+func NodeText(n *xhtml.Node) string {
+	var b strings.Builder
+
+	var walk func(n *xhtml.Node)
+	walk = func(n *xhtml.Node) {
+		switch {
+		case n.Type == xhtml.TextNode:
+			b.WriteString(n.Data)
+		case n.Type == xhtml.ElementNode && (n.Data == "script" || n.Data == "style"):
+			return
+		}
+
+		// Block elements are kept apart, inline ones run on.
+		block := n.Type == xhtml.ElementNode && blockTags[n.Data]
+		if block {
+			b.WriteByte(' ')
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+		if block {
+			b.WriteByte(' ')
+		}
+	}
+	walk(n)
+
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// NodeAttr returns the value of an attribute of a parsed HTML element.
+// It reports false when the element has no such attribute.
+func NodeAttr(n *xhtml.Node, name string) (string, bool) {
+	for _, a := range n.Attr {
+		if a.Namespace == "" && a.Key == name {
+			return a.Val, true
+		}
+	}
+	return "", false
+}
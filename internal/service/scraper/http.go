@@ -0,0 +1,66 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// userAgent identifies job-aggr to the APIs and feeds it reads.
+const userAgent = "job-aggr/1.0 (+https://github.com/brandoyts/job-aggr)"
+
+// maxBodySize bounds the responses read from APIs and feeds.
+const maxBodySize = 32 << 20
+
+// Get fetches url over plain HTTP for sources that don't need a browser.
+// Failures are reported as *Error: 403 and 429 responses as ErrBlocked, timeouts
+// as ErrNavigationTimeout and anything else as ErrNavigation. Cancellation is
+// returned unchanged.
+func Get(ctx context.Context, client *http.Client, source string, url string) ([]byte, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, NewError(source, ErrNavigation, url, err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, NavigationError(source, url, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests:
+		return nil, NewError(source, ErrBlocked, url, fmt.Errorf("HTTP %d", resp.StatusCode))
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return nil, NewError(source, ErrNavigation, url, fmt.Errorf("HTTP %d", resp.StatusCode))
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return nil, NavigationError(source, url, err)
+	}
+
+	return body, nil
+}
+
+// GetJSON fetches url with Get and decodes the JSON response into v.
+// A response that is not the expected JSON is reported as ErrInvalidResponse.
+func GetJSON(ctx context.Context, client *http.Client, source string, url string, v any) error {
+	body, err := Get(ctx, client, source, url)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return NewError(source, ErrInvalidResponse, url, err)
+	}
+
+	return nil
+}
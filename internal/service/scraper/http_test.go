@@ -0,0 +1,95 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGetJSON tests decoding a response and the errors returned for failing requests
+func TestGetJSON(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			assert.Contains(t, r.Header.Get("User-Agent"), "job-aggr")
+			w.Write([]byte(`{"name":"Acme"}`))
+		case "/blocked":
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "/html":
+			w.Write([]byte(`<html>maintenance</html>`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	var v struct{ Name string }
+	assert.NoError(t, GetJSON(context.Background(), nil, "Test", srv.URL+"/ok", &v))
+	assert.Equal(t, "Acme", v.Name)
+
+	err := GetJSON(context.Background(), nil, "Test", srv.URL+"/blocked", &v)
+	assert.ErrorIs(t, err, ErrBlocked)
+
+	err = GetJSON(context.Background(), nil, "Test", srv.URL+"/missing", &v)
+	assert.ErrorIs(t, err, ErrNavigation)
+	assert.ErrorContains(t, err, "HTTP 404")
+
+	err = GetJSON(context.Background(), nil, "Test", srv.URL+"/html", &v)
+	assert.ErrorIs(t, err, ErrInvalidResponse)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = GetJSON(ctx, nil, "Test", srv.URL+"/ok", &v)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// TestHTMLText tests that descriptions keep their paragraphs and lose their markup
+func TestHTMLText(t *testing.T) {
+	assert.Equal(t, "About us\nWe write Go.\nRemote\nHybrid",
+		HTMLText(`<h2>About us</h2><p>We write <b>Go</b>.</p><script>track()</script><ul><li>Remote</li><li>Hybrid</li></ul>`))
+
+	assert.Equal(t, "Fish & Chips", HTMLText(`&lt;p&gt;Fish &amp;amp; Chips&lt;/p&gt;`), "escaped fragments are unescaped")
+	assert.Equal(t, "plain text", HTMLText("plain  text"))
+}
+
+// TestMatch tests the client-side filters of sources that don't search server-side
+func TestMatch(t *testing.T) {
+	assert.True(t, MatchQuery("golang backend", "Backend Engineer", "Golang, Kubernetes"))
+	assert.False(t, MatchQuery("golang backend", "Frontend Engineer", "Golang"))
+	assert.True(t, MatchQuery("", "anything"))
+	assert.True(t, MatchQuery("go", "Senior Go Engineer"))
+	assert.True(t, MatchQuery("Go", "Backend (Go/Kubernetes)"))
+	assert.False(t, MatchQuery("go", "Django Developer"), "whole words only")
+	assert.False(t, MatchQuery("go", "Google Cloud Engineer"))
+	assert.False(t, MatchQuery("c++", "C# Developer"))
+
+	assert.True(t, MatchLocation("Berlin, Germany", "Berlin, DE", false))
+	assert.False(t, MatchLocation("Berlin", "Munich", false))
+	assert.True(t, MatchLocation("Remote", "Anywhere", true))
+	assert.True(t, MatchLocation("", "Munich", false))
+
+	now := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	assert.True(t, MatchPosted(7*24*time.Hour, now.Add(-48*time.Hour), now))
+	assert.False(t, MatchPosted(24*time.Hour, now.Add(-48*time.Hour), now))
+	assert.True(t, MatchPosted(24*time.Hour, time.Time{}, now))
+
+	assert.True(t, MatchRegion("Berlin, Germany", "Germany, Austria"))
+	assert.True(t, MatchRegion("Berlin, Germany", "Worldwide"))
+	assert.True(t, MatchRegion("Berlin, Germany", ""))
+	assert.False(t, MatchRegion("Berlin, Germany", "USA only"))
+	assert.False(t, MatchRegion("Austin, US", "Australia"), "whole words only")
+	assert.True(t, MatchRegion("San Francisco, CA", "USA Only"), "states are in the United States")
+	assert.True(t, MatchRegion("New York, NY", "US"))
+	assert.True(t, MatchRegion("Berlin", "Europe"), "cities are in their country's regions")
+	assert.True(t, MatchRegion("Berlin, Germany", "EU"))
+	assert.True(t, MatchRegion("Toronto, Canada", "Americas"))
+	assert.True(t, MatchRegion("Europe", "Germany only"), "regions hold their countries")
+	assert.True(t, MatchRegion("Springfield", "UK only"), "unknown places aren't ruled out")
+	assert.False(t, MatchRegion("San Francisco, CA", "Europe"))
+	assert.False(t, MatchRegion("London, UK", "EU only"), "the UK is in Europe, not the EU")
+	assert.False(t, MatchRegion("Bangalore, India", "Latin America"))
+}
@@ -0,0 +1,101 @@
+package indeed
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+)
+
+var (
+	// fromageDays are the "posted within" choices Indeed offers, in days.
+	fromageDays = []int{1, 3, 7, 14}
+
+	workplaceAttrs = map[model.WorkplaceType]string{
+		model.WorkplaceRemote: "0kf:attr(DSQF7);",
+		model.WorkplaceHybrid: "0kf:attr(PAXZC);",
+	}
+
+	jobTypes = map[model.JobType]string{
+		model.JobTypeFullTime:   "fulltime",
+		model.JobTypePartTime:   "parttime",
+		model.JobTypeContract:   "contract",
+		model.JobTypeTemporary:  "temporary",
+		model.JobTypeInternship: "internship",
+	}
+
+	experienceLevels = map[model.ExperienceLevel]string{
+		model.ExperienceEntry:     "ENTRY_LEVEL",
+		model.ExperienceMidSenior: "MID_LEVEL",
+		model.ExperienceDirector:  "SENIOR_LEVEL",
+	}
+)
+
+// searchParams translates the criteria into Indeed's search URL parameters and
+// returns the criteria that have no Indeed equivalent.
+func searchParams(c model.SearchCriteria) (url.Values, []string) {
+	params := url.Values{}
+	params.Set("q", c.Query)
+	params.Set("l", c.Location)
+
+	var unsupported []string
+
+	if c.Workplace != "" {
+		if attr, ok := workplaceAttrs[c.Workplace]; ok {
+			params.Set("sc", attr)
+		} else {
+			unsupported = append(unsupported, model.CriterionWorkplace)
+		}
+	}
+
+	if c.RadiusMiles > 0 {
+		params.Set("radius", strconv.Itoa(c.RadiusMiles))
+	}
+
+	if c.PostedWithin > 0 {
+		if days, ok := fromage(c); ok {
+			params.Set("fromage", strconv.Itoa(days))
+		} else {
+			unsupported = append(unsupported, model.CriterionPostedWithin)
+		}
+	}
+
+	if c.JobType != "" {
+		if jt, ok := jobTypes[c.JobType]; ok {
+			params.Set("jt", jt)
+		} else {
+			unsupported = append(unsupported, model.CriterionJobType)
+		}
+	}
+
+	if c.Experience != "" {
+		if level, ok := experienceLevels[c.Experience]; ok {
+			params.Set("explvl", level)
+		} else {
+			unsupported = append(unsupported, model.CriterionExperience)
+		}
+	}
+
+	if c.MinSalary > 0 {
+		unsupported = append(unsupported, model.CriterionMinSalary)
+	}
+
+	if c.Sort == model.SortDate {
+		params.Set("sort", "date")
+	}
+
+	return params, unsupported
+}
+
+// fromage rounds the "posted within" duration up to the closest choice Indeed offers.
+// It reports false when the duration is longer than every choice: cutting it to the
+// longest one would leave out jobs that were asked for.
+func fromage(c model.SearchCriteria) (int, bool) {
+	days := int((c.PostedWithin.Hours() + 23) / 24)
+	for _, d := range fromageDays {
+		if days <= d {
+			return d, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,48 @@
+package indeed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSearchParams tests that criteria are translated into Indeed URL parameters
+func TestSearchParams(t *testing.T) {
+	params, unsupported := searchParams(model.SearchCriteria{
+		Query:        "golang",
+		Location:     "Austin, TX",
+		Workplace:    model.WorkplaceRemote,
+		RadiusMiles:  25,
+		PostedWithin: 48 * time.Hour,
+		JobType:      model.JobTypeContract,
+		Experience:   model.ExperienceEntry,
+		Sort:         model.SortDate,
+	})
+
+	assert.Equal(t, "golang", params.Get("q"))
+	assert.Equal(t, "Austin, TX", params.Get("l"))
+	assert.Equal(t, "0kf:attr(DSQF7);", params.Get("sc"))
+	assert.Equal(t, "25", params.Get("radius"))
+	assert.Equal(t, "3", params.Get("fromage"))
+	assert.Equal(t, "contract", params.Get("jt"))
+	assert.Equal(t, "ENTRY_LEVEL", params.Get("explvl"))
+	assert.Equal(t, "date", params.Get("sort"))
+	assert.Empty(t, unsupported)
+}
+
+// TestSearchParamsUnsupported tests that criteria Indeed can't filter on are reported
+func TestSearchParamsUnsupported(t *testing.T) {
+	params, unsupported := searchParams(model.SearchCriteria{
+		Query:        "golang",
+		Workplace:    model.WorkplaceOnSite,
+		PostedWithin: 30 * 24 * time.Hour,
+		Experience:   model.ExperienceExecutive,
+		MinSalary:    100000,
+	})
+
+	assert.Empty(t, params.Get("sc"))
+	assert.False(t, params.Has("fromage"), "longer than the longest choice, not cut to it")
+	assert.Equal(t, []string{model.CriterionWorkplace, model.CriterionPostedWithin, model.CriterionExperience, model.CriterionMinSalary}, unsupported)
+}
@@ -0,0 +1,68 @@
+package indeed
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/browser"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+)
+
+const jobPath = "/viewjob?jk=%s"
+
+// Enrich visits the job's detail page to fill in its description, salary and employment type.
+func Enrich(ctx context.Context, pool *browser.Pool, job *model.Job) error {
+	return enrich(ctx, pool, DefaultBaseURL, job)
+}
+
+func enrich(ctx context.Context, pool *browser.Pool, baseURL string, job *model.Job) error {
+	if job.ID == "" {
+		return scraper.NewError(sourceName, scraper.ErrSelectorNotFound, "job key", nil)
+	}
+
+	page, err := scraper.Page(ctx, pool, sourceName)
+	if err != nil {
+		return err
+	}
+	defer pool.Put(page)
+
+	page = page.Context(ctx)
+
+	if err := scraper.Navigate(page, sourceName, baseURL+fmt.Sprintf(jobPath, job.ID)); err != nil {
+		return err
+	}
+
+	if description, ok := scraper.PageText(page, "#jobDescriptionText"); ok {
+		job.Description = description
+	}
+
+	if location, ok := scraper.PageText(page, `[data-testid="inlineHeader-companyLocation"]`); ok && job.Location == "" {
+		job.Location = location
+	}
+
+	// The header lists the salary and the employment type, e.g. "$120,000 - $150,000 a year - Full-time".
+	details, err := page.Elements("#salaryInfoAndJobType span")
+	if err != nil {
+		return nil
+	}
+
+	for _, el := range details {
+		text, err := el.Text()
+		if err != nil {
+			continue
+		}
+
+		text = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(text), "-"))
+		switch {
+		case text == "":
+		case strings.ContainsAny(text, "$€£0123456789"):
+			job.Salary = text
+		default:
+			job.EmploymentType = text
+		}
+	}
+
+	return nil
+}
@@ -2,75 +2,39 @@ package indeed
 
 import (
 	"context"
-	"fmt"
-	"log"
-	"net/url"
-	"time"
+	_ "embed"
 
 	"github.com/brandoyts/job-aggr/internal/model"
-	"github.com/go-rod/rod"
-	"github.com/go-rod/rod/lib/launcher"
-	"github.com/go-rod/stealth"
+	"github.com/brandoyts/job-aggr/internal/service/browser"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+	"github.com/brandoyts/job-aggr/internal/service/scraper/spec"
 )
 
-const (
-	indeedBaseUrl   = "https://www.indeed.com"
-	indeedSearchURL = "https://www.indeed.com/jobs?q=%s&l=%s"
-)
-
-func Fetch(ctx context.Context, query string, location string) ([]model.Job, error) {
-	path, _ := launcher.LookPath()
-	u := launcher.New().
-		Headless(true).
-		NoSandbox(true).
-		Bin(path).
-		MustLaunch()
-
-	browser := rod.New().
-		ControlURL(u).
-		Timeout(60 * time.Second).
-		MustConnect()
-
-	defer browser.MustClose()
-
-	page := stealth.MustPage(browser)
+// DefaultBaseURL is the Indeed site searched unless scraper.WithBaseURL picks a regional one.
+const DefaultBaseURL = "https://www.indeed.com"
 
-	parsedJob := url.QueryEscape(query)
-	parsedLocation := url.QueryEscape(location)
+const sourceName = "Indeed"
 
-	url := fmt.Sprintf(indeedSearchURL, parsedJob, parsedLocation)
+//go:embed indeed.yaml
+var specFile []byte
 
-	page.MustNavigate(url).MustWaitLoad().MustWaitIdle()
+// searchSpec describes where jobs are found on Indeed's results pages.
+var searchSpec = spec.MustParse(specFile)
 
-	jobCards, err := page.Elements("div.cardOutline")
-	if err != nil {
-		log.Fatal("Failed selecting job cards:", err)
-		return nil, err
-	}
-
-	var jobs []model.Job
-
-	for _, card := range jobCards {
-		title := card.MustElement("h2").MustText()
-
-		company := ""
-		if c, err := card.Element(`span[data-testid="company-name"]`); err == nil {
-			company = c.MustText()
-		}
-
-		url := ""
-		if l, err := card.Element("a"); err == nil {
-			link, _ := l.Attribute("href")
-			url = *link
-		}
+// Fetch scrapes up to paging.Pages() pages of search results, following Indeed's start= offset.
+// It stops early when a page yields no new job. The search is bounded by ctx only;
+// use Scraper to apply the per-source timeout.
+func Fetch(ctx context.Context, pool *browser.Pool, paging scraper.Paging, criteria model.SearchCriteria) ([]model.Job, error) {
+	return fetch(ctx, pool, DefaultBaseURL, paging, criteria)
+}
 
-		jobs = append(jobs, model.Job{
-			Title:   title,
-			Company: company,
-			Url:     fmt.Sprintf("%s%s", indeedBaseUrl, url),
-			Source:  "Indeed",
-		})
-	}
+func fetch(ctx context.Context, pool *browser.Pool, baseURL string, paging scraper.Paging, criteria model.SearchCriteria) ([]model.Job, error) {
+	params, _ := searchParams(criteria)
 
-	return jobs, nil
+	return spec.Fetch(ctx, pool, searchSpec, spec.Search{
+		BaseURL:  baseURL,
+		Paging:   paging,
+		Criteria: criteria,
+		Params:   params,
+	})
 }
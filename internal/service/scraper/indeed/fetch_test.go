@@ -0,0 +1,37 @@
+package indeed
+
+import (
+	"testing"
+
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+	"github.com/brandoyts/job-aggr/internal/service/scraper/spec/spectest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseResults tests the jobs read from saved results pages against their golden files:
+// sponsored cards, a hidden company, relative links and a search without results
+func TestParseResults(t *testing.T) {
+	for _, name := range []string{"search", "no_results"} {
+		t.Run(name, func(t *testing.T) {
+			spectest.Golden(t, searchSpec, DefaultBaseURL, name)
+		})
+	}
+}
+
+// TestParseResultsRegional tests that links are resolved against a regional site
+func TestParseResultsRegional(t *testing.T) {
+	jobs, err := spectest.Parse(t, searchSpec, "https://uk.indeed.com", "search")
+	require.NoError(t, err)
+	require.NotEmpty(t, jobs)
+	assert.Equal(t, "https://uk.indeed.com/pagead/clk?mo=r&ad=-6NYlbfkN0DxyZ0aT&p=0&fvj=0&vjs=3", jobs[0].Url)
+}
+
+// TestParseResultsErrors tests that blank pages and changed markup are reported
+func TestParseResultsErrors(t *testing.T) {
+	_, err := spectest.Parse(t, searchSpec, DefaultBaseURL, "blank")
+	assert.ErrorIs(t, err, scraper.ErrEmptyPage)
+
+	_, err = spectest.Parse(t, searchSpec, DefaultBaseURL, "markup_changed")
+	assert.ErrorIs(t, err, scraper.ErrSelectorNotFound)
+}
@@ -0,0 +1,136 @@
+package jsonld
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+)
+
+const sourceName = "Career page"
+
+// DefaultSitemapPages is the number of pages read from sitemaps when max_pages isn't set.
+const DefaultSitemapPages = 50
+
+// Fetch reads the pages, then the pages listed by the sitemaps, and keeps the
+// postings matching the criteria. A page or sitemap that cannot be read doesn't
+// stop the others: the postings found are returned along with the error of every
+// configured page and sitemap that couldn't be read. Sitemaps often list pages
+// that are gone, so those are only reported when nothing could be read at all.
+func Fetch(ctx context.Context, client *http.Client, pages []string, sitemaps []string, paging scraper.Paging, criteria model.SearchCriteria) ([]model.Job, error) {
+	if len(pages) == 0 && len(sitemaps) == 0 {
+		return nil, scraper.NewError(sourceName, scraper.ErrNotConfigured, "no urls or sitemaps", nil)
+	}
+
+	var errs, gone []error
+	read := 0
+
+	limit := paging.MaxPages
+	if limit <= 0 {
+		limit = DefaultSitemapPages
+	}
+
+	var listed []string
+	for _, sitemap := range sitemaps {
+		found, err := sitemapPages(ctx, client, sitemap, 0)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			errs = append(errs, err)
+			continue
+		}
+		read++
+		listed = append(listed, found...)
+	}
+	if len(listed) > limit {
+		listed = listed[:limit]
+	}
+
+	collector := scraper.NewCollector(paging, jobKey)
+	now := time.Now()
+	seen := map[string]bool{}
+
+	for i, page := range append(append([]string(nil), pages...), listed...) {
+		if seen[page] {
+			continue
+		}
+		seen[page] = true
+
+		body, err := scraper.Get(ctx, client, sourceName, page)
+		if err != nil {
+			if ctx.Err() != nil {
+				return collector.Jobs(), err
+			}
+			if i < len(pages) {
+				errs = append(errs, err)
+			} else {
+				gone = append(gone, err)
+			}
+			continue
+		}
+		read++
+
+		var jobs []model.Job
+		for _, job := range Parse(body, page) {
+			if matches(criteria, job, now) {
+				jobs = append(jobs, job)
+			}
+		}
+		collector.Add(jobs)
+
+		if collector.Full() {
+			break
+		}
+	}
+
+	if read == 0 {
+		errs = append(errs, gone...)
+	}
+	return collector.Jobs(), errors.Join(errs...)
+}
+
+// matches filters the postings client-side, since career pages can't be searched.
+// Postings past their closing date are dropped.
+func matches(criteria model.SearchCriteria, job model.Job, now time.Time) bool {
+	if !job.ExpiresAt.IsZero() && job.ExpiresAt.Before(now) {
+		return false
+	}
+
+	remote := job.Workplace == model.WorkplaceRemote || strings.Contains(strings.ToLower(job.Location), "remote")
+	if criteria.Workplace == model.WorkplaceRemote && !remote {
+		return false
+	}
+
+	return scraper.MatchQuery(criteria.Query, job.Title) &&
+		scraper.MatchLocation(criteria.Location, job.Location, remote) &&
+		scraper.MatchPosted(criteria.PostedWithin, job.PostedAt, now)
+}
+
+// unsupported lists the criteria matches ignores. Only remote jobs can be told apart.
+func unsupported(c model.SearchCriteria) []string {
+	var names []string
+	for _, name := range c.Criteria() {
+		switch name {
+		case model.CriterionPostedWithin:
+		case model.CriterionWorkplace:
+			if c.Workplace != model.WorkplaceRemote {
+				names = append(names, name)
+			}
+		default:
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func jobKey(job model.Job) string {
+	if job.ID != "" {
+		return job.ID
+	}
+	return job.Url
+}
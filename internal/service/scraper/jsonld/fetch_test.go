@@ -0,0 +1,87 @@
+package jsonld
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+	"github.com/brandoyts/job-aggr/internal/service/scraper/scrapertest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newServer serves the fixtures as a career site with a sitemap index
+func newServer(t *testing.T) *httptest.Server {
+	return scrapertest.NewServer(t, map[string]string{
+		"/careers":            "testdata/careers.html",
+		"/jobs/data-engineer": "testdata/job.html",
+		"/sitemap.xml":        "testdata/sitemap_index.xml",
+		"/sitemap-jobs.xml":   "testdata/sitemap_jobs.xml",
+	}, nil)
+}
+
+func titles(jobs []model.Job) []string {
+	var out []string
+	for _, job := range jobs {
+		out = append(out, job.Title)
+	}
+	return out
+}
+
+// TestFetchPagesAndSitemaps tests reading career pages and the pages their sitemaps list
+func TestFetchPagesAndSitemaps(t *testing.T) {
+	srv := newServer(t)
+
+	jobs, err := Fetch(context.Background(), nil, []string{srv.URL + "/careers"}, []string{srv.URL + "/sitemap.xml"}, scraper.Paging{}, model.SearchCriteria{})
+	require.NoError(t, err, "a listed page that is gone doesn't fail the search")
+	assert.Equal(t, []string{"Senior Go Engineer", "Support Specialist", "Data Engineer"}, titles(jobs), "expired postings are dropped")
+
+	jobs, err = Fetch(context.Background(), nil, nil, []string{srv.URL + "/sitemap.xml"}, scraper.Paging{MaxPages: 1}, model.SearchCriteria{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Data Engineer"}, titles(jobs))
+}
+
+// TestFetchFilters tests that postings are filtered by the search criteria
+func TestFetchFilters(t *testing.T) {
+	srv := newServer(t)
+	pages := []string{srv.URL + "/careers", srv.URL + "/jobs/data-engineer"}
+
+	tests := []struct {
+		name     string
+		criteria model.SearchCriteria
+		want     []string
+	}{
+		{"query", model.SearchCriteria{Query: "engineer"}, []string{"Senior Go Engineer", "Data Engineer"}},
+		{"location", model.SearchCriteria{Location: "Munich"}, []string{"Senior Go Engineer"}},
+		{"remote", model.SearchCriteria{Workplace: model.WorkplaceRemote}, []string{"Support Specialist"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobs, err := Fetch(context.Background(), nil, pages, nil, scraper.Paging{}, tt.criteria)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, titles(jobs))
+		})
+	}
+}
+
+// TestFetchErrors tests that configured pages and sitemaps that can't be read are reported
+func TestFetchErrors(t *testing.T) {
+	srv := newServer(t)
+
+	jobs, err := Fetch(context.Background(), nil, []string{srv.URL + "/missing", srv.URL + "/careers"}, nil, scraper.Paging{}, model.SearchCriteria{})
+	assert.ErrorIs(t, err, scraper.ErrNavigation)
+	assert.ErrorContains(t, err, "/missing")
+	assert.Len(t, jobs, 2, "the postings of the other pages are kept")
+
+	_, err = Fetch(context.Background(), nil, []string{srv.URL + "/missing"}, nil, scraper.Paging{}, model.SearchCriteria{})
+	assert.ErrorIs(t, err, scraper.ErrNavigation)
+
+	_, err = Fetch(context.Background(), nil, nil, []string{srv.URL + "/careers"}, scraper.Paging{}, model.SearchCriteria{})
+	assert.ErrorIs(t, err, scraper.ErrInvalidResponse, "a page that isn't a sitemap")
+
+	_, err = Fetch(context.Background(), nil, nil, nil, scraper.Paging{}, model.SearchCriteria{})
+	assert.ErrorIs(t, err, scraper.ErrNotConfigured)
+}
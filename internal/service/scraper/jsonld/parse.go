@@ -0,0 +1,326 @@
+package jsonld
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// dateLayouts are the forms datePosted and validThrough are found in.
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	time.DateOnly,
+}
+
+var employmentTypes = map[string]string{
+	"FULL_TIME":  "Full-time",
+	"PART_TIME":  "Part-time",
+	"CONTRACTOR": "Contract",
+	"TEMPORARY":  "Temporary",
+	"INTERN":     "Internship",
+	"VOLUNTEER":  "Volunteer",
+	"PER_DIEM":   "Per diem",
+}
+
+var periods = map[string]model.SalaryPeriod{
+	"HOUR":  model.PeriodHour,
+	"DAY":   model.PeriodDay,
+	"WEEK":  model.PeriodWeek,
+	"MONTH": model.PeriodMonth,
+	"YEAR":  model.PeriodYear,
+}
+
+// Parse extracts the schema.org JobPostings embedded as JSON-LD in an HTML page.
+// Postings without a URL of their own link to pageURL, which also resolves relative URLs.
+// Scripts that aren't valid JSON are skipped.
+func Parse(page []byte, pageURL string) []model.Job {
+	base, _ := url.Parse(pageURL)
+
+	var jobs []model.Job
+	for _, script := range scripts(page) {
+		var v any
+		if err := json.Unmarshal(script, &v); err != nil {
+			continue
+		}
+
+		for _, posting := range postings(v) {
+			if job, ok := toJob(posting, base); ok {
+				jobs = append(jobs, job)
+			}
+		}
+	}
+	return jobs
+}
+
+// scripts returns the content of the page's application/ld+json scripts.
+func scripts(page []byte) [][]byte {
+	var out [][]byte
+	z := html.NewTokenizer(bytes.NewReader(page))
+	inScript := false
+
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return out
+		case html.StartTagToken:
+			name, hasAttr := z.TagName()
+			inScript = false
+			if atom.Lookup(name) != atom.Script {
+				continue
+			}
+			for hasAttr {
+				var key, val []byte
+				key, val, hasAttr = z.TagAttr()
+				if string(key) == "type" && strings.EqualFold(strings.TrimSpace(string(val)), "application/ld+json") {
+					inScript = true
+				}
+			}
+		case html.TextToken:
+			if inScript {
+				out = append(out, bytes.Clone(z.Text()))
+			}
+		default:
+			inScript = false
+		}
+	}
+}
+
+// postings finds the JobPostings of a JSON-LD document, be it a single object, an
+// array, a @graph or a list nesting them.
+func postings(v any) []map[string]any {
+	switch v := v.(type) {
+	case []any:
+		var out []map[string]any
+		for _, item := range v {
+			out = append(out, postings(item)...)
+		}
+		return out
+	case map[string]any:
+		if isType(v["@type"], "JobPosting") {
+			return []map[string]any{v}
+		}
+		var out []map[string]any
+		for _, key := range []string{"@graph", "itemListElement", "item", "mainEntity"} {
+			out = append(out, postings(v[key])...)
+		}
+		return out
+	}
+	return nil
+}
+
+// isType reports whether a @type, a string or an array of them, is want.
+func isType(v any, want string) bool {
+	switch v := v.(type) {
+	case string:
+		return v == want || strings.TrimPrefix(v, "http://schema.org/") == want || strings.TrimPrefix(v, "https://schema.org/") == want
+	case []any:
+		for _, t := range v {
+			if isType(t, want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func toJob(p map[string]any, base *url.URL) (model.Job, bool) {
+	job := model.Job{
+		Title:          scraper.HTMLText(text(p["title"])),
+		Company:        name(p["hiringOrganization"]),
+		Location:       location(p["jobLocation"]),
+		Source:         sourceName,
+		Description:    scraper.HTMLText(text(p["description"])),
+		EmploymentType: employmentType(p["employmentType"]),
+		PostedAt:       date(p["datePosted"]),
+		ExpiresAt:      date(p["validThrough"]),
+		Pay:            pay(p["baseSalary"]),
+	}
+	if job.Title == "" {
+		return job, false
+	}
+
+	job.Salary = job.Pay.String()
+
+	if strings.EqualFold(text(p["jobLocationType"]), "TELECOMMUTE") {
+		job.Workplace = model.WorkplaceRemote
+		if job.Location == "" {
+			job.Location = "Remote"
+		}
+	}
+
+	// Jobs are keyed by URL. Postings sharing the page's URL are told apart by their
+	// identifier, or their title when they don't have one.
+	job.Url = resolve(base, text(p["url"]))
+	if job.Url == "" {
+		if base != nil {
+			job.Url = base.String()
+		}
+		id := identifier(p["identifier"])
+		if id == "" {
+			id = job.Title
+		}
+		job.ID = job.Url + "#" + id
+	}
+
+	return job, true
+}
+
+// text returns a string or number value, or "" for anything else.
+func text(v any) string {
+	switch v := v.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return ""
+}
+
+// name returns a value that is either a string or a thing with a name or value,
+// e.g. an Organization or a PropertyValue.
+func name(v any) string {
+	if m, ok := v.(map[string]any); ok {
+		if n := text(m["name"]); n != "" {
+			return n
+		}
+		return text(m["value"])
+	}
+	return text(v)
+}
+
+// identifier returns a PropertyValue's value, or the identifier itself when it is plain text.
+func identifier(v any) string {
+	if m, ok := v.(map[string]any); ok {
+		return text(m["value"])
+	}
+	return text(v)
+}
+
+// location formats one or more Places, e.g. "Berlin, Germany / Munich, Germany".
+func location(v any) string {
+	var places []string
+	switch v := v.(type) {
+	case []any:
+		for _, place := range v {
+			if l := location(place); l != "" {
+				places = append(places, l)
+			}
+		}
+	case map[string]any:
+		address, ok := v["address"].(map[string]any)
+		if !ok {
+			return text(v["address"])
+		}
+		var parts []string
+		for _, key := range []string{"addressLocality", "addressRegion", "addressCountry"} {
+			if part := name(address[key]); part != "" && !containsFold(parts, part) {
+				parts = append(parts, part)
+			}
+		}
+		return strings.Join(parts, ", ")
+	case string:
+		return strings.TrimSpace(v)
+	}
+	return strings.Join(places, " / ")
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// employmentType formats one or more employment types, e.g. "FULL_TIME" as "Full-time".
+func employmentType(v any) string {
+	var types []string
+	switch v := v.(type) {
+	case []any:
+		for _, t := range v {
+			if s := employmentType(t); s != "" {
+				types = append(types, s)
+			}
+		}
+	case string:
+		key := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(v), "-", "_"))
+		if t, ok := employmentTypes[key]; ok {
+			return t
+		}
+		return strings.TrimSpace(v)
+	}
+	return strings.Join(types, ", ")
+}
+
+// date parses a date or date-time, returning the zero time when it can't be.
+func date(v any) time.Time {
+	s := text(v)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC()
+		}
+	}
+	return time.Time{}
+}
+
+// pay reads a MonetaryAmount whose value is either a number or a QuantitativeValue.
+func pay(v any) model.Salary {
+	amount, ok := v.(map[string]any)
+	if !ok {
+		return model.Salary{}
+	}
+
+	s := model.Salary{Currency: strings.ToUpper(text(amount["currency"]))}
+
+	value, ok := amount["value"].(map[string]any)
+	if !ok {
+		value = map[string]any{"value": amount["value"]}
+	}
+
+	s.Min, s.Max = number(value["minValue"]), number(value["maxValue"])
+	if s.Min == 0 && s.Max == 0 {
+		s.Min = number(value["value"])
+		s.Max = s.Min
+	}
+	s.Period = periods[strings.ToUpper(text(value["unitText"]))]
+	if s.Period == "" {
+		s.Period = periods[strings.ToUpper(text(amount["unitText"]))]
+	}
+
+	if s.IsZero() {
+		return model.Salary{}
+	}
+	return s
+}
+
+// number reads a number that may be written as a string, e.g. "120000" or "120,000".
+func number(v any) float64 {
+	f, _ := strconv.ParseFloat(strings.ReplaceAll(text(v), ",", ""), 64)
+	return f
+}
+
+func resolve(base *url.URL, ref string) string {
+	if ref == "" {
+		return ""
+	}
+	u, err := url.Parse(ref)
+	if err != nil {
+		return ""
+	}
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+	return u.String()
+}
@@ -0,0 +1,63 @@
+package jsonld
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseCareersPage tests extracting every posting of a @graph, skipping other types and broken scripts
+func TestParseCareersPage(t *testing.T) {
+	page, err := os.ReadFile("testdata/careers.html")
+	require.NoError(t, err)
+
+	jobs := Parse(page, "https://acme.example/careers")
+	require.Len(t, jobs, 3)
+
+	assert.Equal(t, model.Job{
+		Title:          "Senior Go Engineer",
+		Company:        "Acme GmbH",
+		Location:       "Berlin, DE / Munich, DE",
+		Url:            "https://acme.example/careers/senior-go-engineer",
+		Source:         "Career page",
+		Salary:         "EUR 75,000–95,000 / year",
+		Description:    "Build payments in Go.",
+		EmploymentType: "Full-time, Contract",
+		PostedAt:       time.Date(2024, 5, 8, 0, 0, 0, 0, time.UTC),
+		ExpiresAt:      time.Date(2099, 12, 31, 22, 59, 59, 0, time.UTC),
+		Pay:            model.Salary{Min: 75000, Max: 95000, Currency: "EUR", Period: model.PeriodYear},
+	}, jobs[0])
+
+	support := jobs[1]
+	assert.Equal(t, "Acme GmbH", support.Company, "organizations may be plain names")
+	assert.Equal(t, "Remote", support.Location)
+	assert.Equal(t, model.WorkplaceRemote, support.Workplace)
+	assert.Equal(t, model.Salary{Min: 25, Max: 25, Currency: "EUR", Period: model.PeriodHour}, support.Pay)
+	assert.Equal(t, "Part-time", support.EmploymentType)
+
+	assert.Equal(t, "Berlin, Germany", jobs[2].Location, "addresses may be plain text")
+	assert.Equal(t, "Internship", jobs[2].EmploymentType)
+}
+
+// TestParseJobPage tests a posting without a URL of its own
+func TestParseJobPage(t *testing.T) {
+	page, err := os.ReadFile("testdata/job.html")
+	require.NoError(t, err)
+
+	jobs := Parse(page, "https://acme.example/jobs/data-engineer")
+	require.Len(t, jobs, 1)
+
+	job := jobs[0]
+	assert.Equal(t, "https://acme.example/jobs/data-engineer", job.Url)
+	assert.Equal(t, "https://acme.example/jobs/data-engineer#DE-42", job.ID)
+	assert.Equal(t, "Hamburg, Germany", job.Location)
+	assert.Equal(t, time.Date(2024, 5, 6, 8, 0, 0, 0, time.UTC), job.PostedAt)
+	assert.Equal(t, "Full-time", job.EmploymentType)
+	assert.True(t, job.Pay.IsZero())
+
+	assert.Empty(t, Parse([]byte("<html><body>No openings</body></html>"), "https://acme.example"))
+}
@@ -0,0 +1,27 @@
+// Package jsonld reads the schema.org JobPostings that career pages embed as
+// JSON-LD, so companies can be followed without a scraper written for their site.
+package jsonld
+
+import (
+	"context"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+)
+
+// DefaultTimeout bounds a whole search when no other timeout is configured.
+const DefaultTimeout = 2 * time.Minute
+
+// NewScraper creates a scraper reading the given career pages, then the pages listed
+// by the given sitemaps.
+func NewScraper(pages []string, sitemaps []string, opts ...scraper.Option) *scraper.Scraper {
+	return scraper.NewScraper(scraper.Definition{
+		Name: sourceName,
+		Fetch: func(ctx context.Context, s scraper.Settings, criteria model.SearchCriteria) ([]model.Job, error) {
+			return Fetch(ctx, s.Client, pages, sitemaps, s.Paging, criteria)
+		},
+		Unsupported: unsupported,
+		Defaults:    scraper.Settings{Timeout: DefaultTimeout},
+	}, opts...)
+}
@@ -0,0 +1,65 @@
+package jsonld
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+)
+
+// urlset is a sitemap, or a sitemap index when it lists sitemaps instead of pages.
+type urlset struct {
+	XMLName  xml.Name
+	URLs     []loc `xml:"url"`
+	Sitemaps []loc `xml:"sitemap"`
+}
+
+type loc struct {
+	Loc string `xml:"loc"`
+}
+
+// maxSitemapDepth bounds how deep sitemap indexes are followed.
+const maxSitemapDepth = 2
+
+// sitemapPages returns the pages listed by a sitemap, following sitemap indexes.
+func sitemapPages(ctx context.Context, client *http.Client, sitemapURL string, depth int) ([]string, error) {
+	body, err := scraper.Get(ctx, client, sourceName, sitemapURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var set urlset
+	err = xml.Unmarshal(body, &set)
+	if err == nil && set.XMLName.Local != "urlset" && set.XMLName.Local != "sitemapindex" {
+		err = fmt.Errorf("unexpected <%s> element", set.XMLName.Local)
+	}
+	if err != nil {
+		return nil, scraper.NewError(sourceName, scraper.ErrInvalidResponse, sitemapURL, err)
+	}
+
+	var pages []string
+	for _, u := range set.URLs {
+		if u.Loc != "" {
+			pages = append(pages, u.Loc)
+		}
+	}
+
+	if depth >= maxSitemapDepth {
+		return pages, nil
+	}
+
+	for _, s := range set.Sitemaps {
+		nested, err := sitemapPages(ctx, client, s.Loc, depth+1)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			continue
+		}
+		pages = append(pages, nested...)
+	}
+
+	return pages, nil
+}
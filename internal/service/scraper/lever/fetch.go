@@ -0,0 +1,172 @@
+package lever
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+)
+
+// DefaultBaseURL is Lever's public postings API.
+const DefaultBaseURL = "https://api.lever.co"
+
+const (
+	sourceName = "Lever"
+
+	// postingsPath lists every published posting of a company.
+	postingsPath = "/v0/postings/%s?mode=json"
+)
+
+type posting struct {
+	ID               string `json:"id"`
+	Text             string `json:"text"` // the title
+	HostedURL        string `json:"hostedUrl"`
+	ApplyURL         string `json:"applyUrl"`
+	CreatedAt        int64  `json:"createdAt"` // milliseconds since the epoch
+	WorkplaceType    string `json:"workplaceType"`
+	Description      string `json:"description"` // HTML
+	DescriptionPlain string `json:"descriptionPlain"`
+	AdditionalPlain  string `json:"additionalPlain"`
+	Lists            []struct {
+		Text    string `json:"text"`
+		Content string `json:"content"` // HTML list items
+	} `json:"lists"`
+	Categories struct {
+		Commitment   string   `json:"commitment"`
+		Department   string   `json:"department"`
+		Team         string   `json:"team"`
+		Location     string   `json:"location"`
+		AllLocations []string `json:"allLocations"`
+	} `json:"categories"`
+	SalaryRange *struct {
+		Currency string  `json:"currency"`
+		Interval string  `json:"interval"`
+		Min      float64 `json:"min"`
+		Max      float64 `json:"max"`
+	} `json:"salaryRange"`
+}
+
+// workplaces maps Lever's workplace types. "unspecified" is left out.
+var workplaces = map[string]model.WorkplaceType{
+	"onsite": model.WorkplaceOnSite,
+	"hybrid": model.WorkplaceHybrid,
+	"remote": model.WorkplaceRemote,
+}
+
+// periods maps the intervals of salary ranges, e.g. "per-year-salary".
+var periods = map[string]model.SalaryPeriod{
+	"per-hour-wage":    model.PeriodHour,
+	"per-day-wage":     model.PeriodDay,
+	"per-week-salary":  model.PeriodWeek,
+	"per-month-salary": model.PeriodMonth,
+	"per-year-salary":  model.PeriodYear,
+}
+
+// Fetch reads the postings of every company and keeps the ones matching the criteria.
+func Fetch(ctx context.Context, client *http.Client, baseURL string, companies []string, paging scraper.Paging, criteria model.SearchCriteria) ([]model.Job, error) {
+	now := time.Now()
+
+	return scraper.FetchCompanies(ctx, sourceName, companies, paging, jobKey, func(ctx context.Context, company string) ([]model.Job, error) {
+		var postings []posting
+		if err := scraper.GetJSON(ctx, client, sourceName, baseURL+fmt.Sprintf(postingsPath, url.PathEscape(company)), &postings); err != nil {
+			return nil, err
+		}
+
+		var jobs []model.Job
+		for _, p := range postings {
+			if matches(criteria, p, now) {
+				jobs = append(jobs, toJob(company, p))
+			}
+		}
+		return jobs, nil
+	})
+}
+
+func toJob(company string, p posting) model.Job {
+	job := model.Job{
+		ID:             p.ID,
+		Title:          p.Text,
+		Company:        company, // postings don't name the company
+		Location:       p.Categories.Location,
+		Url:            p.HostedURL,
+		ApplyUrl:       p.ApplyURL,
+		Source:         sourceName,
+		Description:    description(p),
+		EmploymentType: p.Categories.Commitment,
+		Department:     scraper.Department(p.Categories.Department, p.Categories.Team),
+		Workplace:      workplaces[p.WorkplaceType],
+	}
+
+	if p.CreatedAt > 0 {
+		job.PostedAt = time.UnixMilli(p.CreatedAt).UTC()
+	}
+
+	if r := p.SalaryRange; r != nil {
+		job.Pay = model.Salary{Min: r.Min, Max: r.Max, Currency: r.Currency, Period: periods[r.Interval]}
+		job.Salary = job.Pay.String()
+	}
+
+	return job
+}
+
+// description puts the opening, the lists of requirements and the closing together.
+func description(p posting) string {
+	parts := []string{p.DescriptionPlain}
+	if parts[0] == "" {
+		parts[0] = scraper.HTMLText(p.Description)
+	}
+
+	for _, list := range p.Lists {
+		parts = append(parts, list.Text+"\n"+scraper.HTMLText(list.Content))
+	}
+	parts = append(parts, p.AdditionalPlain)
+
+	var nonEmpty []string
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	return strings.Join(nonEmpty, "\n\n")
+}
+
+// matches filters the postings client-side, since the API returns every posting of a company.
+func matches(criteria model.SearchCriteria, p posting, now time.Time) bool {
+	c := p.Categories
+	locations := strings.Join(append([]string{c.Location}, c.AllLocations...), " / ")
+	workplace := workplaces[p.WorkplaceType]
+	remote := workplace == model.WorkplaceRemote
+
+	if criteria.Workplace != "" && workplace != "" && workplace != criteria.Workplace {
+		return false
+	}
+
+	var posted time.Time
+	if p.CreatedAt > 0 {
+		posted = time.UnixMilli(p.CreatedAt)
+	}
+
+	return scraper.MatchQuery(criteria.Query, p.Text, c.Team, c.Department) &&
+		scraper.MatchLocation(criteria.Location, locations, remote) &&
+		scraper.MatchPosted(criteria.PostedWithin, posted, now)
+}
+
+// unsupported lists the criteria matches ignores.
+func unsupported(c model.SearchCriteria) []string {
+	var names []string
+	for _, name := range c.Criteria() {
+		if name != model.CriterionWorkplace && name != model.CriterionPostedWithin {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func jobKey(job model.Job) string {
+	return job.ID
+}
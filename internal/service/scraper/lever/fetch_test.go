@@ -0,0 +1,97 @@
+package lever
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+	"github.com/brandoyts/job-aggr/internal/service/scraper/scrapertest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newServer serves the postings fixture for the "acme" company and fails every other one
+func newServer(t *testing.T) *httptest.Server {
+	return scrapertest.NewServer(t, map[string]string{"/v0/postings/acme": "testdata/postings.json"}, func(r *http.Request) {
+		assert.Equal(t, "json", r.URL.Query().Get("mode"))
+	})
+}
+
+// TestFetchMapsPostings tests that every field of a posting is mapped
+func TestFetchMapsPostings(t *testing.T) {
+	srv := newServer(t)
+
+	jobs, err := Fetch(context.Background(), nil, srv.URL, []string{"acme"}, scraper.Paging{}, model.SearchCriteria{})
+	require.NoError(t, err)
+	require.Len(t, jobs, 3)
+
+	assert.Equal(t, model.Job{
+		ID:             "5ac21346-8e0c-4494-8e7a-3eb92ff77902",
+		Title:          "Backend Engineer, Payments",
+		Company:        "acme",
+		Location:       "Berlin",
+		Url:            "https://jobs.lever.co/acme/5ac21346-8e0c-4494-8e7a-3eb92ff77902",
+		ApplyUrl:       "https://jobs.lever.co/acme/5ac21346-8e0c-4494-8e7a-3eb92ff77902/apply",
+		Source:         "Lever",
+		Salary:         "EUR 70,000–90,000 / year",
+		Description:    "Acme moves money.\n\nWhat you'll do\nBuild payment APIs in Go\nOwn reliability\n\nWe offer a learning budget.",
+		EmploymentType: "Full-time",
+		Department:     "Engineering / Payments",
+		Workplace:      model.WorkplaceHybrid,
+		PostedAt:       time.Date(2024, 5, 9, 10, 30, 0, 0, time.UTC),
+		Pay:            model.Salary{Min: 70000, Max: 90000, Currency: "EUR", Period: model.PeriodYear},
+	}, jobs[0])
+
+	assert.Equal(t, "Keep the lights on.", jobs[1].Description, "the HTML description is used without a plain one")
+	assert.Equal(t, "Engineering", jobs[1].Department)
+	assert.Equal(t, model.WorkplaceRemote, jobs[1].Workplace)
+	assert.Empty(t, jobs[1].Salary)
+	assert.Equal(t, "Operations", jobs[2].Department)
+}
+
+// TestFetchFilters tests that postings are filtered by the search criteria
+func TestFetchFilters(t *testing.T) {
+	srv := newServer(t)
+
+	tests := []struct {
+		name     string
+		criteria model.SearchCriteria
+		want     []string
+	}{
+		{"query matches title", model.SearchCriteria{Query: "backend"}, []string{"Backend Engineer, Payments"}},
+		{"query matches team", model.SearchCriteria{Query: "operations"}, []string{"Office Manager"}},
+		{"any location", model.SearchCriteria{Location: "Hamburg"}, []string{"Backend Engineer, Payments", "Office Manager"}},
+		{"remote location", model.SearchCriteria{Location: "Remote"}, []string{"Site Reliability Engineer"}},
+		{"workplace", model.SearchCriteria{Workplace: model.WorkplaceOnSite}, []string{"Office Manager"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobs, err := Fetch(context.Background(), nil, srv.URL, []string{"acme"}, scraper.Paging{}, tt.criteria)
+			require.NoError(t, err)
+
+			var titles []string
+			for _, job := range jobs {
+				titles = append(titles, job.Title)
+			}
+			assert.Equal(t, tt.want, titles)
+		})
+	}
+}
+
+// TestFetchCompanyErrors tests that a failing company is reported along with the jobs of the others
+func TestFetchCompanyErrors(t *testing.T) {
+	srv := newServer(t)
+
+	jobs, err := Fetch(context.Background(), nil, srv.URL, []string{"missing", "acme"}, scraper.Paging{}, model.SearchCriteria{})
+	assert.ErrorIs(t, err, scraper.ErrNavigation)
+	assert.ErrorContains(t, err, "/postings/missing")
+	assert.Len(t, jobs, 3)
+
+	_, err = Fetch(context.Background(), nil, srv.URL, []string{"missing"}, scraper.Paging{}, model.SearchCriteria{})
+	assert.ErrorIs(t, err, scraper.ErrNavigation)
+}
@@ -0,0 +1,22 @@
+package lever
+
+import (
+	"github.com/brandoyts/job-aggr/internal/config"
+	"github.com/brandoyts/job-aggr/internal/service/aggregator"
+	"github.com/brandoyts/job-aggr/internal/service/browser"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+)
+
+func init() {
+	scraper.Register(scraper.Source{
+		Name:         "lever",
+		Label:        sourceName,
+		Capabilities: scraper.Capabilities{Salary: true, RemoteFilter: true},
+		New: func(_ *browser.Pool, cfg config.Source) (aggregator.JobScraper, error) {
+			return NewScraper(cfg.Companies, scraper.ConfigOptions(cfg)...), nil
+		},
+		Configured: func(cfg config.Source) bool {
+			return len(cfg.Companies) > 0
+		},
+	})
+}
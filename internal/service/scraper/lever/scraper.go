@@ -0,0 +1,23 @@
+// Package lever reads the career sites companies host on Lever through its public
+// postings API, without a browser.
+package lever
+
+import (
+	"context"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+)
+
+// NewScraper creates a scraper reading the given career sites, identified by the
+// company's slug: the "acme" of jobs.lever.co/acme.
+func NewScraper(sites []string, opts ...scraper.Option) *scraper.Scraper {
+	return scraper.NewScraper(scraper.Definition{
+		Name: sourceName,
+		Fetch: func(ctx context.Context, s scraper.Settings, criteria model.SearchCriteria) ([]model.Job, error) {
+			return Fetch(ctx, s.Client, s.BaseURL, sites, s.Paging, criteria)
+		},
+		Unsupported: unsupported,
+		Defaults:    scraper.Settings{BaseURL: DefaultBaseURL},
+	}, opts...)
+}
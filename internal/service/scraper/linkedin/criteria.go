@@ -0,0 +1,107 @@
+package linkedin
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+)
+
+var (
+	workplaceTypes = map[model.WorkplaceType]string{
+		model.WorkplaceOnSite: "1",
+		model.WorkplaceRemote: "2",
+		model.WorkplaceHybrid: "3",
+	}
+
+	jobTypes = map[model.JobType]string{
+		model.JobTypeFullTime:   "F",
+		model.JobTypePartTime:   "P",
+		model.JobTypeContract:   "C",
+		model.JobTypeTemporary:  "T",
+		model.JobTypeInternship: "I",
+	}
+
+	experienceLevels = map[model.ExperienceLevel]string{
+		model.ExperienceInternship: "1",
+		model.ExperienceEntry:      "2",
+		model.ExperienceAssociate:  "3",
+		model.ExperienceMidSenior:  "4",
+		model.ExperienceDirector:   "5",
+		model.ExperienceExecutive:  "6",
+	}
+)
+
+// salaryBuckets are the yearly salary floors behind LinkedIn's f_SB2 filter, in USD.
+var salaryBuckets = []int{40_000, 60_000, 80_000, 100_000, 120_000, 140_000, 160_000, 180_000, 200_000}
+
+// searchParams translates the criteria into LinkedIn's search URL parameters and
+// returns the criteria that have no LinkedIn equivalent.
+func searchParams(c model.SearchCriteria) (url.Values, []string) {
+	params := url.Values{}
+	params.Set("keywords", c.Query)
+	params.Set("location", c.Location)
+
+	var unsupported []string
+
+	if c.Workplace != "" {
+		if wt, ok := workplaceTypes[c.Workplace]; ok {
+			params.Set("f_WT", wt)
+		} else {
+			unsupported = append(unsupported, model.CriterionWorkplace)
+		}
+	}
+
+	if c.RadiusMiles > 0 {
+		params.Set("distance", strconv.Itoa(c.RadiusMiles))
+	}
+
+	if c.PostedWithin > 0 {
+		params.Set("f_TPR", "r"+strconv.Itoa(int(c.PostedWithin.Seconds())))
+	}
+
+	if c.JobType != "" {
+		if jt, ok := jobTypes[c.JobType]; ok {
+			params.Set("f_JT", jt)
+		} else {
+			unsupported = append(unsupported, model.CriterionJobType)
+		}
+	}
+
+	if c.Experience != "" {
+		if level, ok := experienceLevels[c.Experience]; ok {
+			params.Set("f_E", level)
+		} else {
+			unsupported = append(unsupported, model.CriterionExperience)
+		}
+	}
+
+	if c.MinSalary > 0 {
+		if bucket := salaryBucket(c.MinSalary); bucket > 0 {
+			params.Set("f_SB2", strconv.Itoa(bucket))
+		} else {
+			unsupported = append(unsupported, model.CriterionMinSalary)
+		}
+	}
+
+	switch c.Sort {
+	case model.SortDate:
+		params.Set("sortBy", "DD")
+	case model.SortRelevance:
+		params.Set("sortBy", "R")
+	}
+
+	return params, unsupported
+}
+
+// salaryBucket returns the highest f_SB2 bucket whose floor doesn't exceed min,
+// or zero when min is below the lowest bucket.
+func salaryBucket(min int) int {
+	bucket := 0
+	for i, floor := range salaryBuckets {
+		if min >= floor {
+			bucket = i + 1
+		}
+	}
+	return bucket
+}
@@ -0,0 +1,43 @@
+package linkedin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSearchParams tests that criteria are translated into LinkedIn URL parameters
+func TestSearchParams(t *testing.T) {
+	params, unsupported := searchParams(model.SearchCriteria{
+		Query:        "golang",
+		Location:     "Berlin",
+		Workplace:    model.WorkplaceHybrid,
+		RadiusMiles:  10,
+		PostedWithin: 24 * time.Hour,
+		JobType:      model.JobTypeFullTime,
+		Experience:   model.ExperienceMidSenior,
+		MinSalary:    125000,
+		Sort:         model.SortDate,
+	})
+
+	assert.Equal(t, "golang", params.Get("keywords"))
+	assert.Equal(t, "Berlin", params.Get("location"))
+	assert.Equal(t, "3", params.Get("f_WT"))
+	assert.Equal(t, "10", params.Get("distance"))
+	assert.Equal(t, "r86400", params.Get("f_TPR"))
+	assert.Equal(t, "F", params.Get("f_JT"))
+	assert.Equal(t, "4", params.Get("f_E"))
+	assert.Equal(t, "5", params.Get("f_SB2"))
+	assert.Equal(t, "DD", params.Get("sortBy"))
+	assert.Empty(t, unsupported)
+}
+
+// TestSearchParamsSalaryBelowBuckets tests that a salary floor below LinkedIn's lowest bucket is reported
+func TestSearchParamsSalaryBelowBuckets(t *testing.T) {
+	params, unsupported := searchParams(model.SearchCriteria{Query: "golang", MinSalary: 30000})
+
+	assert.Empty(t, params.Get("f_SB2"))
+	assert.Equal(t, []string{model.CriterionMinSalary}, unsupported)
+}
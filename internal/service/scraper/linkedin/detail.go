@@ -0,0 +1,63 @@
+package linkedin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/browser"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+)
+
+// Enrich visits the job's detail page to fill in its description, salary,
+// employment type and posting date.
+func Enrich(ctx context.Context, pool *browser.Pool, job *model.Job) error {
+	return enrich(ctx, pool, DefaultBaseURL, job)
+}
+
+func enrich(ctx context.Context, pool *browser.Pool, baseURL string, job *model.Job) error {
+	if job.ID == "" {
+		return scraper.NewError(sourceName, scraper.ErrSelectorNotFound, "job view ID", nil)
+	}
+
+	page, err := scraper.Page(ctx, pool, sourceName)
+	if err != nil {
+		return err
+	}
+	defer pool.Put(page)
+
+	page = page.Context(ctx)
+
+	if err := scraper.Navigate(page, sourceName, baseURL+fmt.Sprintf(jobPath, job.ID)); err != nil {
+		return err
+	}
+
+	if description, ok := scraper.PageText(page, "div.show-more-less-html__markup"); ok {
+		job.Description = description
+	}
+
+	if salary, ok := scraper.PageText(page, "div.salary.compensation__salary"); ok {
+		job.Salary = salary
+	}
+
+	if posted, ok := scraper.PageText(page, "span.posted-time-ago__text"); ok && job.PostedAt.IsZero() {
+		job.PostedAt = scraper.ParsePosted(posted, time.Now())
+	}
+
+	// Job criteria are listed as header/value pairs, e.g. "Employment type: Full-time".
+	criteria, err := page.Elements("li.description__job-criteria-item")
+	if err != nil {
+		return nil
+	}
+
+	for _, item := range criteria {
+		header, _ := scraper.Text(item, "h3.description__job-criteria-subheader")
+		if strings.EqualFold(header, "Employment type") {
+			job.EmploymentType, _ = scraper.Text(item, "span.description__job-criteria-text")
+		}
+	}
+
+	return nil
+}
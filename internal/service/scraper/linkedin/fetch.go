@@ -2,78 +2,42 @@ package linkedin
 
 import (
 	"context"
-	"fmt"
-	"net/url"
-	"time"
+	_ "embed"
 
 	"github.com/brandoyts/job-aggr/internal/model"
-	"github.com/go-rod/rod"
-	"github.com/go-rod/rod/lib/launcher"
-	"github.com/go-rod/stealth"
+	"github.com/brandoyts/job-aggr/internal/service/browser"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+	"github.com/brandoyts/job-aggr/internal/service/scraper/spec"
 )
 
+// DefaultBaseURL is the LinkedIn site searched unless scraper.WithBaseURL picks another one.
+const DefaultBaseURL = "https://www.linkedin.com"
+
 const (
-	linkedinSearchURL = "https://www.linkedin.com/jobs/search/?keywords=%s&location=%s"
+	sourceName = "LinkedIn"
+	jobPath    = "/jobs/view/%s"
 )
 
-func Fetch(ctx context.Context, query string, location string) ([]model.Job, error) {
-	path, _ := launcher.LookPath()
-	u := launcher.New().
-		Headless(true).  // required for backend
-		NoSandbox(true). // needed for Docker and some Linux servers
-		Bin(path).       // auto-detect chrome/chromium
-		MustLaunch()
-
-	browser := rod.New().
-		ControlURL(u).
-		Timeout(60 * time.Second).
-		MustConnect()
-
-	defer browser.MustClose()
-
-	page := stealth.MustPage(browser) // anti-bot cloak
-
-	parsedJob := url.QueryEscape(query)
-	parsedLocation := url.QueryEscape(location)
-
-	url := fmt.Sprintf(linkedinSearchURL, parsedJob, parsedLocation)
+//go:embed linkedin.yaml
+var specFile []byte
 
-	page.MustNavigate(url).MustWaitLoad().MustWaitIdle()
+// searchSpec describes where jobs are found on LinkedIn's results page.
+var searchSpec = spec.MustParse(specFile)
 
-	jobCards, err := page.Elements("div.job-search-card")
-	if err != nil {
-		return nil, err
-	}
-
-	var jobs []model.Job
-
-	for _, card := range jobCards {
-		title := card.MustElement("h3").MustText()
-
-		company := ""
-		if c, err := card.Element("a.hidden-nested-link"); err == nil {
-			company = c.MustText()
-		}
-
-		url := ""
-		if l, err := card.Element("a.base-card__full-link"); err == nil {
-			link, _ := l.Attribute("href")
-			url = *link
-		}
-
-		location := ""
-		if c, err := card.Element("span.job-search-card__location"); err == nil {
-			location = c.MustText()
-		}
+// Fetch scrapes the search results, scrolling for more up to paging.Pages() times.
+// It stops early when scrolling yields no new job. The search is bounded by ctx only;
+// use Scraper to apply the per-source timeout.
+func Fetch(ctx context.Context, pool *browser.Pool, paging scraper.Paging, criteria model.SearchCriteria) ([]model.Job, error) {
+	return fetch(ctx, pool, DefaultBaseURL, paging, criteria)
+}
 
-		jobs = append(jobs, model.Job{
-			Title:    title,
-			Company:  company,
-			Location: location,
-			Url:      url,
-			Source:   "LinkedIn",
-		})
-	}
+func fetch(ctx context.Context, pool *browser.Pool, baseURL string, paging scraper.Paging, criteria model.SearchCriteria) ([]model.Job, error) {
+	params, _ := searchParams(criteria)
 
-	return jobs, nil
+	return spec.Fetch(ctx, pool, searchSpec, spec.Search{
+		BaseURL:  baseURL,
+		Paging:   paging,
+		Criteria: criteria,
+		Params:   params,
+	})
 }
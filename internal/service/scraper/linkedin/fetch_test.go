@@ -0,0 +1,38 @@
+package linkedin
+
+import (
+	"testing"
+
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+	"github.com/brandoyts/job-aggr/internal/service/scraper/spec/spectest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseResults tests the jobs read from saved results pages against their golden files:
+// promoted cards, companies without a page or missing, relative links and a search without results
+func TestParseResults(t *testing.T) {
+	for _, name := range []string{"search", "no_results"} {
+		t.Run(name, func(t *testing.T) {
+			spectest.Golden(t, searchSpec, DefaultBaseURL, name)
+		})
+	}
+}
+
+// TestParseResultsRelativeLinks tests that relative links are resolved against the site searched
+func TestParseResultsRelativeLinks(t *testing.T) {
+	jobs, err := spectest.Parse(t, searchSpec, "https://de.linkedin.com", "search")
+	require.NoError(t, err)
+	require.Len(t, jobs, 4)
+	assert.Equal(t, "https://de.linkedin.com/jobs/view/golang-engineer-at-stealth-startup-3805555555", jobs[2].Url)
+	assert.Equal(t, "3805555555", jobs[2].ID)
+}
+
+// TestParseResultsErrors tests that blank pages and changed markup are reported
+func TestParseResultsErrors(t *testing.T) {
+	_, err := spectest.Parse(t, searchSpec, DefaultBaseURL, "blank")
+	assert.ErrorIs(t, err, scraper.ErrEmptyPage)
+
+	_, err = spectest.Parse(t, searchSpec, DefaultBaseURL, "markup_changed")
+	assert.ErrorIs(t, err, scraper.ErrSelectorNotFound)
+}
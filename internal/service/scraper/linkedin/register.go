@@ -0,0 +1,19 @@
+package linkedin
+
+import (
+	"github.com/brandoyts/job-aggr/internal/config"
+	"github.com/brandoyts/job-aggr/internal/service/aggregator"
+	"github.com/brandoyts/job-aggr/internal/service/browser"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+)
+
+func init() {
+	scraper.Register(scraper.Source{
+		Name:         "linkedin",
+		Label:        sourceName,
+		Capabilities: scraper.Capabilities{Pagination: true, Salary: true, RemoteFilter: true},
+		New: func(pool *browser.Pool, cfg config.Source) (aggregator.JobScraper, error) {
+			return NewScraper(pool, scraper.ConfigOptions(cfg)...), nil
+		},
+	})
+}
@@ -4,14 +4,37 @@ import (
 	"context"
 
 	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/browser"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
 )
 
-type Scraper struct{}
+// Scraper searches LinkedIn in the browser pages of its pool, and enriches the jobs found.
+type Scraper struct {
+	*scraper.Scraper
+	pool *browser.Pool
+}
 
-func NewScraper() *Scraper {
-	return &Scraper{}
+func NewScraper(pool *browser.Pool, opts ...scraper.Option) *Scraper {
+	return &Scraper{
+		Scraper: scraper.NewScraper(scraper.Definition{
+			Name: sourceName,
+			Fetch: func(ctx context.Context, s scraper.Settings, criteria model.SearchCriteria) ([]model.Job, error) {
+				return fetch(ctx, pool, s.BaseURL, s.Paging, criteria)
+			},
+			Unsupported: func(criteria model.SearchCriteria) []string {
+				_, unsupported := searchParams(criteria)
+				return unsupported
+			},
+			Defaults: scraper.Settings{BaseURL: DefaultBaseURL},
+		}, opts...),
+		pool: pool,
+	}
 }
 
-func (s *Scraper) Fetch(ctx context.Context, query string, location string) ([]model.Job, error) {
-	return Fetch(ctx, query, location)
+// Enrich fills in the job's details from its detail page, bounded by the scraper timeout.
+func (s *Scraper) Enrich(ctx context.Context, job *model.Job) error {
+	ctx, cancel := s.Bound(ctx)
+	defer cancel()
+
+	return enrich(ctx, s.pool, s.Settings().BaseURL, job)
 }
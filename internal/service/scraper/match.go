@@ -0,0 +1,95 @@
+package scraper
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// The functions below filter jobs client-side, for sources whose APIs return every
+// posting of a company instead of searching.
+
+// MatchQuery reports whether every word of query occurs in one of texts as a whole
+// word, ignoring case, so "go" doesn't match "Django". An empty query matches everything.
+func MatchQuery(query string, texts ...string) bool {
+	haystack := phrase(strings.Join(texts, " "))
+
+	for _, word := range strings.Fields(phrase(query)) {
+		if !strings.Contains(haystack, " "+word+" ") {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MatchLocation reports whether a job located at jobLocation matches the searched
+// location. The first part of location, typically the city, must occur in
+// jobLocation; searching for "remote" matches remote jobs. An empty location matches everything.
+func MatchLocation(location string, jobLocation string, remote bool) bool {
+	city, _, _ := strings.Cut(location, ",")
+	city = strings.ToLower(strings.TrimSpace(city))
+
+	switch {
+	case city == "":
+		return true
+	case city == "remote":
+		return remote || strings.Contains(strings.ToLower(jobLocation), "remote")
+	}
+
+	return strings.Contains(strings.ToLower(jobLocation), city)
+}
+
+// MatchPosted reports whether a job posted at posted is no older than within at now.
+// Jobs without a known posting date always match, as does a zero within.
+func MatchPosted(within time.Duration, posted time.Time, now time.Time) bool {
+	if within <= 0 || posted.IsZero() {
+		return true
+	}
+	return !posted.Before(now.Add(-within))
+}
+
+// anywhere are the words remote boards use for jobs open to applicants anywhere.
+var anywhere = []string{"worldwide", "anywhere", "global"}
+
+// MatchRegion reports whether applicants from location may apply to a remote job
+// restricted to regions, e.g. "USA only". Jobs open worldwide or without a stated
+// restriction always match, as do an empty location and "remote". Otherwise location
+// is resolved to its country, e.g. "Berlin" to Germany, and the country or one of its
+// regions, e.g. "EU", must occur in regions as whole words. Locations that can't be
+// resolved match, rather than dropping jobs their applicants may well apply to.
+func MatchRegion(location string, regions string) bool {
+	location = strings.ToLower(strings.TrimSpace(location))
+	regions = phrase(regions)
+
+	if location == "" || location == "remote" || strings.TrimSpace(regions) == "" {
+		return true
+	}
+	for _, word := range anywhere {
+		if strings.Contains(regions, " "+word+" ") {
+			return true
+		}
+	}
+
+	for _, part := range strings.Split(location, ",") {
+		if part = phrase(part); strings.TrimSpace(part) != "" && strings.Contains(regions, part) {
+			return true
+		}
+	}
+
+	names := areas(location)
+	if names == nil {
+		return true
+	}
+	return anyWord(regions, names, 0)
+}
+
+// phrase lowercases the words of s and joins them with single spaces, padded with
+// one more on each side so whole words can be looked up with strings.Contains.
+// The symbols of names like "C++" and "C#" are part of words.
+func phrase(s string) string {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#'
+	})
+	return " " + strings.Join(words, " ") + " "
+}
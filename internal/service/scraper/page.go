@@ -0,0 +1,117 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/service/browser"
+	"github.com/go-rod/rod"
+)
+
+// IdleTimeout bounds how long we wait for the network to settle after a page load.
+const IdleTimeout = time.Minute
+
+// blockedMarkers are fragments of page titles or URLs served instead of the
+// requested page when a site decides we are a bot.
+var blockedMarkers = []string{
+	"just a moment",
+	"security check",
+	"captcha",
+	"authwall",
+	"checkpoint/challenge",
+	"access denied",
+}
+
+// Page checks out a page from the pool, wrapping failures as ErrLaunch.
+func Page(ctx context.Context, pool *browser.Pool, source string) (*rod.Page, error) {
+	page, err := pool.Page(ctx)
+	if err != nil {
+		return nil, launchError(source, err)
+	}
+	return page, nil
+}
+
+// Navigate opens url and waits for the page to load and settle, then checks
+// that the site did not serve a captcha or login wall instead.
+func Navigate(page *rod.Page, source string, url string) error {
+	if err := page.Navigate(url); err != nil {
+		return NavigationError(source, url, err)
+	}
+
+	if err := page.WaitLoad(); err != nil {
+		return NavigationError(source, url, err)
+	}
+
+	if err := page.WaitIdle(IdleTimeout); err != nil {
+		return NavigationError(source, url, err)
+	}
+
+	if blocked(page) {
+		return NewError(source, ErrBlocked, url, nil)
+	}
+
+	return nil
+}
+
+// Text returns the trimmed text of the first child of el matching selector.
+// It reports false when the child doesn't exist.
+func Text(el *rod.Element, selector string) (string, bool) {
+	child, err := el.Element(selector)
+	if err != nil {
+		return "", false
+	}
+
+	text, err := child.Text()
+	if err != nil {
+		return "", false
+	}
+
+	return strings.TrimSpace(text), true
+}
+
+// PageText returns the trimmed text of the first element of the page matching selector,
+// without waiting for it to appear. It reports false when there is no such element.
+func PageText(page *rod.Page, selector string) (string, bool) {
+	found, el, err := page.Has(selector)
+	if err != nil || !found {
+		return "", false
+	}
+
+	text, err := el.Text()
+	if err != nil {
+		return "", false
+	}
+
+	return strings.TrimSpace(text), true
+}
+
+// NavigationError wraps an error met while loading a page as ErrNavigation, or
+// ErrNavigationTimeout when a deadline expired. detail is usually the URL involved.
+// Cancellation is returned unchanged so callers can still detect it.
+func NavigationError(source string, detail string, err error) error {
+	if errors.Is(err, context.Canceled) {
+		return err
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return NewError(source, ErrNavigationTimeout, detail, err)
+	}
+	return NewError(source, ErrNavigation, detail, err)
+}
+
+func blocked(page *rod.Page) bool {
+	info, err := page.Info()
+	if err != nil {
+		return false
+	}
+
+	haystack := strings.ToLower(info.Title + " " + info.URL)
+	for _, marker := range blockedMarkers {
+		if strings.Contains(haystack, marker) {
+			return true
+		}
+	}
+
+	return false
+}
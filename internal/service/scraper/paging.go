@@ -0,0 +1,67 @@
+package scraper
+
+import "github.com/brandoyts/job-aggr/internal/model"
+
+// DefaultMaxPages is the number of result pages read when none is configured.
+const DefaultMaxPages = 3
+
+// Paging limits how far a scraper follows the result pages of a search.
+type Paging struct {
+	MaxPages   int // result pages to read at most, DefaultMaxPages when zero
+	MaxResults int // jobs to return at most, unlimited when zero
+}
+
+// Pages returns the effective number of pages to read.
+func (p Paging) Pages() int {
+	if p.MaxPages <= 0 {
+		return DefaultMaxPages
+	}
+	return p.MaxPages
+}
+
+// Collector accumulates jobs across result pages, dropping jobs seen on a previous page.
+type Collector struct {
+	paging Paging
+	key    func(model.Job) string
+	seen   map[string]bool
+	jobs   []model.Job
+}
+
+// NewCollector creates a collector that identifies jobs by key.
+func NewCollector(paging Paging, key func(model.Job) string) *Collector {
+	return &Collector{
+		paging: paging,
+		key:    key,
+		seen:   map[string]bool{},
+	}
+}
+
+// Add keeps the jobs that were not seen before, up to MaxResults, and returns how many were kept.
+func (c *Collector) Add(jobs []model.Job) int {
+	added := 0
+	for _, job := range jobs {
+		if c.Full() {
+			break
+		}
+
+		k := c.key(job)
+		if c.seen[k] {
+			continue
+		}
+
+		c.seen[k] = true
+		c.jobs = append(c.jobs, job)
+		added++
+	}
+	return added
+}
+
+// Full reports whether MaxResults jobs have been collected.
+func (c *Collector) Full() bool {
+	return c.paging.MaxResults > 0 && len(c.jobs) >= c.paging.MaxResults
+}
+
+// Jobs returns the collected jobs in the order they were added.
+func (c *Collector) Jobs() []model.Job {
+	return c.jobs
+}
@@ -0,0 +1,40 @@
+package scraper
+
+import (
+	"testing"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func byUrl(job model.Job) string {
+	return job.Url
+}
+
+// TestCollectorDropsDuplicates tests that jobs seen on a previous page are not added again
+func TestCollectorDropsDuplicates(t *testing.T) {
+	c := NewCollector(Paging{}, byUrl)
+
+	assert.Equal(t, 2, c.Add([]model.Job{{Url: "/a"}, {Url: "/b"}}))
+	assert.Equal(t, 1, c.Add([]model.Job{{Url: "/b"}, {Url: "/c"}}))
+	assert.Equal(t, 0, c.Add([]model.Job{{Url: "/a"}, {Url: "/c"}}))
+
+	assert.Equal(t, []model.Job{{Url: "/a"}, {Url: "/b"}, {Url: "/c"}}, c.Jobs())
+	assert.False(t, c.Full())
+}
+
+// TestCollectorMaxResults tests that the collector stops at MaxResults
+func TestCollectorMaxResults(t *testing.T) {
+	c := NewCollector(Paging{MaxResults: 2}, byUrl)
+
+	assert.Equal(t, 2, c.Add([]model.Job{{Url: "/a"}, {Url: "/b"}, {Url: "/c"}}))
+	assert.True(t, c.Full())
+	assert.Equal(t, 0, c.Add([]model.Job{{Url: "/d"}}))
+	assert.Len(t, c.Jobs(), 2)
+}
+
+// TestPagingPages tests the default number of pages
+func TestPagingPages(t *testing.T) {
+	assert.Equal(t, DefaultMaxPages, Paging{}.Pages())
+	assert.Equal(t, 5, Paging{MaxPages: 5}.Pages())
+}
@@ -0,0 +1,53 @@
+package scraper
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var relativeDatePattern = regexp.MustCompile(`(\d+)\+?\s*(minute|hour|day|week|month|year)s?\s+ago`)
+
+// ParsePosted converts the posting dates shown by job boards, either absolute
+// ("2024-05-01") or relative ("Posted 3 days ago", "30+ days ago", "Just posted"),
+// into a time. It returns the zero time when the text isn't understood.
+func ParsePosted(text string, now time.Time) time.Time {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if text == "" {
+		return time.Time{}
+	}
+
+	if t, err := time.Parse("2006-01-02", text); err == nil {
+		return t
+	}
+
+	if strings.Contains(text, "just posted") || strings.Contains(text, "today") {
+		return now
+	}
+
+	if strings.Contains(text, "yesterday") {
+		return now.AddDate(0, 0, -1)
+	}
+
+	m := relativeDatePattern.FindStringSubmatch(text)
+	if m == nil {
+		return time.Time{}
+	}
+
+	n, _ := strconv.Atoi(m[1])
+	switch m[2] {
+	case "minute":
+		return now.Add(-time.Duration(n) * time.Minute)
+	case "hour":
+		return now.Add(-time.Duration(n) * time.Hour)
+	case "day":
+		return now.AddDate(0, 0, -n)
+	case "week":
+		return now.AddDate(0, 0, -7*n)
+	case "month":
+		return now.AddDate(0, -n, 0)
+	default:
+		return now.AddDate(-n, 0, 0)
+	}
+}
@@ -0,0 +1,30 @@
+package scraper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParsePosted tests absolute and relative posting dates
+func TestParsePosted(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]time.Time{
+		"2024-05-01":         time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		"Just posted":        now,
+		"Posted Today":       now,
+		"Posted 3 days ago":  now.AddDate(0, 0, -3),
+		"30+ days ago":       now.AddDate(0, 0, -30),
+		"2 weeks ago":        now.AddDate(0, 0, -14),
+		"1 month ago":        now.AddDate(0, -1, 0),
+		"5 hours ago":        now.Add(-5 * time.Hour),
+		"Employer active 3d": {},
+		"":                   {},
+	}
+
+	for text, want := range tests {
+		assert.Equal(t, want, ParsePosted(text, now), text)
+	}
+}
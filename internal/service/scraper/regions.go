@@ -0,0 +1,142 @@
+package scraper
+
+import (
+	"slices"
+	"strings"
+)
+
+// country is a country as locations and the region restrictions of remote jobs name
+// it: by its names and by the regions it belongs to, all lowercase.
+type country struct {
+	names   []string
+	regions []string
+}
+
+var (
+	europeEU    = []string{"europe", "eu", "emea"}
+	europe      = []string{"europe", "emea"}
+	northAm     = []string{"north america", "americas"}
+	latinAm     = []string{"latin america", "latam", "south america", "americas"}
+	asiaPacific = []string{"asia", "apac"}
+	oceania     = []string{"oceania", "apac"}
+	africa      = []string{"africa", "emea"}
+	middleEast  = []string{"middle east", "emea"}
+)
+
+// countries lists the countries locations are resolved to. Two-letter names are only
+// recognized as a whole part of a location, e.g. the "US" of "Austin, US".
+var countries = []country{
+	{[]string{"united states", "united states of america", "usa", "us", "u s", "u s a"}, northAm},
+	{[]string{"canada"}, northAm},
+	{[]string{"mexico"}, latinAm},
+	{[]string{"brazil", "brasil"}, latinAm},
+	{[]string{"argentina"}, latinAm},
+	{[]string{"colombia"}, latinAm},
+	{[]string{"chile"}, latinAm},
+	{[]string{"united kingdom", "uk", "great britain", "britain", "england", "scotland", "wales", "gb"}, europe},
+	{[]string{"ireland"}, europeEU},
+	{[]string{"germany", "deutschland"}, europeEU},
+	{[]string{"france"}, europeEU},
+	{[]string{"spain", "espana"}, europeEU},
+	{[]string{"portugal"}, europeEU},
+	{[]string{"italy"}, europeEU},
+	{[]string{"netherlands", "the netherlands", "holland"}, europeEU},
+	{[]string{"belgium"}, europeEU},
+	{[]string{"austria"}, europeEU},
+	{[]string{"poland"}, europeEU},
+	{[]string{"sweden"}, europeEU},
+	{[]string{"denmark"}, europeEU},
+	{[]string{"finland"}, europeEU},
+	{[]string{"czech republic", "czechia"}, europeEU},
+	{[]string{"romania"}, europeEU},
+	{[]string{"greece"}, europeEU},
+	{[]string{"switzerland"}, europe},
+	{[]string{"norway"}, europe},
+	{[]string{"ukraine"}, europe},
+	{[]string{"israel"}, middleEast},
+	{[]string{"united arab emirates", "uae"}, middleEast},
+	{[]string{"south africa"}, africa},
+	{[]string{"nigeria"}, africa},
+	{[]string{"kenya"}, africa},
+	{[]string{"india"}, asiaPacific},
+	{[]string{"philippines"}, asiaPacific},
+	{[]string{"singapore"}, asiaPacific},
+	{[]string{"japan"}, asiaPacific},
+	{[]string{"australia"}, oceania},
+	{[]string{"new zealand"}, oceania},
+}
+
+// places maps cities and US states, by name or postal code, to their country's first name.
+var places = map[string]string{
+	"san francisco": "united states", "new york": "united states", "seattle": "united states",
+	"austin": "united states", "boston": "united states", "chicago": "united states",
+	"los angeles": "united states", "denver": "united states", "atlanta": "united states",
+	"toronto": "canada", "vancouver": "canada", "montreal": "canada",
+	"london": "united kingdom", "manchester": "united kingdom", "edinburgh": "united kingdom",
+	"dublin": "ireland", "berlin": "germany", "munich": "germany", "hamburg": "germany",
+	"paris": "france", "madrid": "spain", "barcelona": "spain", "lisbon": "portugal",
+	"amsterdam": "netherlands", "stockholm": "sweden", "copenhagen": "denmark",
+	"warsaw": "poland", "vienna": "austria", "zurich": "switzerland", "prague": "czech republic",
+	"bangalore": "india", "bengaluru": "india", "mumbai": "india", "delhi": "india",
+	"manila": "philippines", "tokyo": "japan", "sydney": "australia", "melbourne": "australia",
+	"sao paulo": "brazil", "mexico city": "mexico", "tel aviv": "israel",
+}
+
+// usStates are the names and postal codes of the states of the United States.
+var usStates = []string{
+	"al", "alabama", "ak", "alaska", "az", "arizona", "ar", "arkansas", "ca", "california",
+	"co", "colorado", "ct", "connecticut", "de", "delaware", "dc", "district of columbia",
+	"fl", "florida", "ga", "georgia", "hi", "hawaii", "id", "idaho", "il", "illinois",
+	"in", "indiana", "ia", "iowa", "ks", "kansas", "ky", "kentucky", "la", "louisiana",
+	"me", "maine", "md", "maryland", "ma", "massachusetts", "mi", "michigan", "mn", "minnesota",
+	"ms", "mississippi", "mo", "missouri", "mt", "montana", "ne", "nebraska", "nv", "nevada",
+	"nh", "new hampshire", "nj", "new jersey", "nm", "new mexico", "ny", "new york",
+	"nc", "north carolina", "nd", "north dakota", "oh", "ohio", "ok", "oklahoma", "or", "oregon",
+	"pa", "pennsylvania", "ri", "rhode island", "sc", "south carolina", "sd", "south dakota",
+	"tn", "tennessee", "tx", "texas", "ut", "utah", "vt", "vermont", "va", "virginia",
+	"wa", "washington", "wv", "west virginia", "wi", "wisconsin", "wy", "wyoming",
+}
+
+func init() {
+	for _, state := range usStates {
+		places[state] = "united states"
+	}
+}
+
+// areas returns the names a region restriction may use for location: those of its
+// country and the regions the country belongs to. A location that is itself a region,
+// e.g. "Europe", also has the names of every country in it. It returns nil when
+// location can't be resolved.
+func areas(location string) []string {
+	var found []string
+	for _, part := range strings.Split(location, ",") {
+		part = strings.TrimSpace(phrase(part))
+		if part == "" {
+			continue
+		}
+
+		if name, ok := places[part]; ok {
+			part = name
+		}
+		for _, c := range countries {
+			if slices.Contains(c.names, part) || len(part) > 2 && anyWord(" "+part+" ", c.names, 2) {
+				found = append(found, c.names...)
+				found = append(found, c.regions...)
+			} else if slices.Contains(c.regions, part) {
+				found = append(found, part)
+				found = append(found, c.names...)
+			}
+		}
+	}
+	return found
+}
+
+// anyWord reports whether one of names longer than minLen occurs in the phrase p as whole words.
+func anyWord(p string, names []string, minLen int) bool {
+	for _, n := range names {
+		if len(n) > minLen && strings.Contains(p, " "+n+" ") {
+			return true
+		}
+	}
+	return false
+}
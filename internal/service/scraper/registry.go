@@ -0,0 +1,135 @@
+package scraper
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/brandoyts/job-aggr/internal/config"
+	"github.com/brandoyts/job-aggr/internal/service/aggregator"
+	"github.com/brandoyts/job-aggr/internal/service/browser"
+)
+
+// Capabilities describes what a source supports, for users picking sources.
+type Capabilities struct {
+	Pagination   bool // reads more than one page of results
+	Salary       bool // reports salaries
+	RemoteFilter bool // can search for remote jobs only
+}
+
+// List names the supported capabilities, e.g. ["pagination", "salary"].
+func (c Capabilities) List() []string {
+	var list []string
+	if c.Pagination {
+		list = append(list, "pagination")
+	}
+	if c.Salary {
+		list = append(list, "salary")
+	}
+	if c.RemoteFilter {
+		list = append(list, "remote filter")
+	}
+	return list
+}
+
+// Source describes a source in the registry.
+type Source struct {
+	Name         string // used in config files and on the command line, e.g. "indeed"
+	Label        string // shown to users, e.g. "Indeed"
+	Capabilities Capabilities
+
+	// New creates a scraper for the source. pool is shared by browser-based sources
+	// and may be ignored by the others.
+	New func(pool *browser.Pool, cfg config.Source) (aggregator.JobScraper, error)
+
+	// Configured reports whether the source has the settings it needs, e.g. the
+	// companies to read from. Sources without it need no settings.
+	Configured func(cfg config.Source) bool
+
+	// OptIn sources are only searched once enabled in the config file, e.g. those
+	// covering a niche that would add noise to every search.
+	OptIn bool
+}
+
+// Usable reports whether the source can be searched with the given settings.
+func (s Source) Usable(cfg config.Source) bool {
+	return s.Configured == nil || s.Configured(cfg)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Source{}
+)
+
+// Register makes a source available by name. It is meant to be called from the init
+// function of the source's package, and panics if the name is already taken.
+func Register(s Source) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if s.Name == "" || s.New == nil {
+		panic("scraper: Register needs a name and a constructor")
+	}
+	if _, dup := registry[s.Name]; dup {
+		panic(fmt.Sprintf("scraper: Register called twice for source %q", s.Name))
+	}
+	registry[s.Name] = s
+}
+
+// Lookup returns the source registered under name.
+func Lookup(name string) (Source, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	s, ok := registry[name]
+	return s, ok
+}
+
+// Sources returns every registered source, sorted by name.
+func Sources() []Source {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	sources := make([]Source, 0, len(registry))
+	for _, s := range registry {
+		sources = append(sources, s)
+	}
+	sort.Slice(sources, func(i, j int) bool { return sources[i].Name < sources[j].Name })
+	return sources
+}
+
+// Names returns the names of every registered source, sorted.
+func Names() []string {
+	sources := Sources()
+	names := make([]string, len(sources))
+	for i, s := range sources {
+		names[i] = s.Name
+	}
+	return names
+}
+
+// Enabled returns the names of the sources that are enabled in cfg and have the
+// settings they need, sorted. Opt-in sources must be enabled explicitly.
+func Enabled(cfg config.Config) []string {
+	var names []string
+	for _, s := range Sources() {
+		if src := cfg.Source(s.Name); src.IsEnabled() && s.Usable(src) && (!s.OptIn || src.Enabled != nil) {
+			names = append(names, s.Name)
+		}
+	}
+	return names
+}
+
+// New creates the scraper of the named source with its settings from cfg.
+func New(name string, pool *browser.Pool, cfg config.Source) (aggregator.JobScraper, error) {
+	s, ok := Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown source %q", name)
+	}
+
+	scraper, err := s.New(pool, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return scraper, nil
+}
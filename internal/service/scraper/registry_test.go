@@ -0,0 +1,83 @@
+package scraper
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/brandoyts/job-aggr/internal/config"
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/aggregator"
+	"github.com/brandoyts/job-aggr/internal/service/browser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// staticScraper remembers the settings it was created with
+type staticScraper struct {
+	cfg config.Source
+}
+
+func (s staticScraper) Fetch(ctx context.Context, criteria model.SearchCriteria) ([]model.Job, error) {
+	return nil, nil
+}
+
+// TestRegistry tests registering, listing and creating sources
+func TestRegistry(t *testing.T) {
+	Register(Source{
+		Name:         "test-static",
+		Label:        "Static",
+		Capabilities: Capabilities{Salary: true},
+		New: func(pool *browser.Pool, cfg config.Source) (aggregator.JobScraper, error) {
+			return staticScraper{cfg: cfg}, nil
+		},
+	})
+
+	src, ok := Lookup("test-static")
+	require.True(t, ok)
+	assert.Equal(t, "Static", src.Label)
+	assert.Equal(t, []string{"salary"}, src.Capabilities.List())
+	assert.Contains(t, Names(), "test-static")
+
+	s, err := New("test-static", nil, config.Source{MaxPages: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 2, s.(staticScraper).cfg.MaxPages)
+
+	_, err = New("test-missing", nil, config.Source{})
+	assert.ErrorContains(t, err, `unknown source "test-missing"`)
+
+	assert.Panics(t, func() {
+		Register(Source{Name: "test-static", New: src.New})
+	}, "names are unique")
+}
+
+// TestEnabled tests that sources missing settings and opt-in sources aren't searched by default
+func TestEnabled(t *testing.T) {
+	newStatic := func(pool *browser.Pool, cfg config.Source) (aggregator.JobScraper, error) {
+		return staticScraper{cfg: cfg}, nil
+	}
+	Register(Source{Name: "test-enabled-plain", New: newStatic})
+	Register(Source{Name: "test-enabled-companies", New: newStatic, Configured: func(cfg config.Source) bool {
+		return len(cfg.Companies) > 0
+	}})
+	Register(Source{Name: "test-enabled-optin", New: newStatic, OptIn: true})
+
+	enabled := func(cfg config.Config) []string {
+		var names []string
+		for _, name := range Enabled(cfg) {
+			if strings.HasPrefix(name, "test-enabled-") {
+				names = append(names, name)
+			}
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"test-enabled-plain"}, enabled(config.Config{}))
+
+	yes := true
+	cfg := config.Config{Sources: map[string]config.Source{
+		"test-enabled-companies": {Companies: []string{"acme"}},
+		"test-enabled-optin":     {Enabled: &yes},
+	}}
+	assert.Equal(t, []string{"test-enabled-companies", "test-enabled-optin", "test-enabled-plain"}, enabled(cfg))
+}
@@ -0,0 +1,116 @@
+package remoteok
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+)
+
+// DefaultBaseURL is the Remote OK site, whose feed lists the latest jobs.
+const DefaultBaseURL = "https://remoteok.com"
+
+const (
+	sourceName = "Remote OK"
+
+	feedPath = "/api"
+)
+
+// posting is an element of the feed. The first element is a legal notice rather
+// than a job, and has no ID.
+type posting struct {
+	ID          json.Number `json:"id"` // a string or a number
+	Epoch       int64       `json:"epoch"`
+	Company     string      `json:"company"`
+	Position    string      `json:"position"`
+	Tags        []string    `json:"tags"`
+	Description string      `json:"description"` // HTML
+	Location    string      `json:"location"`    // where applicants must be based, e.g. "Worldwide"
+	ApplyURL    string      `json:"apply_url"`
+	URL         string      `json:"url"`
+	SalaryMin   float64     `json:"salary_min"` // yearly, in USD
+	SalaryMax   float64     `json:"salary_max"`
+}
+
+// Fetch reads the feed and keeps the jobs matching the criteria. Every job is
+// remote, so searching for another workplace finds nothing.
+func Fetch(ctx context.Context, client *http.Client, baseURL string, paging scraper.Paging, criteria model.SearchCriteria) ([]model.Job, error) {
+	if criteria.Workplace != "" && criteria.Workplace != model.WorkplaceRemote {
+		return nil, nil
+	}
+
+	var postings []posting
+	if err := scraper.GetJSON(ctx, client, sourceName, baseURL+feedPath, &postings); err != nil {
+		return nil, err
+	}
+
+	collector := scraper.NewCollector(paging, jobKey)
+	now := time.Now()
+
+	var jobs []model.Job
+	for _, p := range postings {
+		if p.ID == "" || p.Position == "" {
+			continue
+		}
+		job := toJob(p)
+		if matches(criteria, job, now) {
+			jobs = append(jobs, job)
+		}
+	}
+	collector.Add(jobs)
+
+	return collector.Jobs(), nil
+}
+
+func toJob(p posting) model.Job {
+	job := model.Job{
+		ID:          p.ID.String(),
+		Title:       strings.TrimSpace(p.Position),
+		Company:     strings.TrimSpace(p.Company),
+		Location:    "Remote",
+		Url:         p.URL,
+		ApplyUrl:    p.ApplyURL,
+		Source:      sourceName,
+		Description: scraper.HTMLText(p.Description),
+		Workplace:   model.WorkplaceRemote,
+		Regions:     strings.TrimSpace(p.Location),
+		Tags:        p.Tags,
+	}
+
+	if p.Epoch > 0 {
+		job.PostedAt = time.Unix(p.Epoch, 0).UTC()
+	}
+
+	if p.SalaryMin > 0 || p.SalaryMax > 0 {
+		job.Pay = model.Salary{Min: p.SalaryMin, Max: p.SalaryMax, Currency: "USD", Period: model.PeriodYear}
+		job.Salary = job.Pay.String()
+	}
+
+	return job
+}
+
+// matches filters the feed client-side, since it lists every recent job.
+func matches(criteria model.SearchCriteria, job model.Job, now time.Time) bool {
+	return scraper.MatchQuery(criteria.Query, job.Title, strings.Join(job.Tags, " ")) &&
+		scraper.MatchRegion(criteria.Location, job.Regions) &&
+		scraper.MatchPosted(criteria.PostedWithin, job.PostedAt, now)
+}
+
+// unsupported lists the criteria matches ignores.
+func unsupported(c model.SearchCriteria) []string {
+	var names []string
+	for _, name := range c.Criteria() {
+		if name != model.CriterionWorkplace && name != model.CriterionPostedWithin {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func jobKey(job model.Job) string {
+	return job.ID
+}
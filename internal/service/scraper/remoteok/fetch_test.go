@@ -0,0 +1,78 @@
+package remoteok
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+	"github.com/brandoyts/job-aggr/internal/service/scraper/scrapertest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newServer serves the feed fixture
+func newServer(t *testing.T) *httptest.Server {
+	return scrapertest.NewServer(t, map[string]string{"/api": "testdata/feed.json"}, nil)
+}
+
+// TestFetchMapsJobs tests that jobs are mapped along with their tags, salary and region, skipping the legal notice
+func TestFetchMapsJobs(t *testing.T) {
+	srv := newServer(t)
+
+	jobs, err := Fetch(context.Background(), nil, srv.URL, scraper.Paging{}, model.SearchCriteria{})
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+
+	assert.Equal(t, model.Job{
+		ID:          "123456",
+		Title:       "Senior Go Engineer",
+		Company:     "Acme",
+		Location:    "Remote",
+		Url:         "https://remoteok.com/remote-jobs/remote-senior-go-engineer-acme-123456",
+		ApplyUrl:    "https://remoteok.com/remote-jobs/123456/apply",
+		Source:      "Remote OK",
+		Salary:      "USD 120,000–160,000 / year",
+		Description: "Build APIs.",
+		Workplace:   model.WorkplaceRemote,
+		Regions:     "Worldwide",
+		Tags:        []string{"golang", "backend", "senior"},
+		PostedAt:    time.Unix(1715000000, 0).UTC(),
+		Pay:         model.Salary{Min: 120000, Max: 160000, Currency: "USD", Period: model.PeriodYear},
+	}, jobs[0])
+
+	assert.Equal(t, "123457", jobs[1].ID, "numeric IDs are read too")
+	assert.Empty(t, jobs[1].Salary)
+}
+
+// TestFetchFilters tests that the feed is filtered by the search criteria
+func TestFetchFilters(t *testing.T) {
+	srv := newServer(t)
+
+	tests := []struct {
+		name     string
+		criteria model.SearchCriteria
+		want     []string
+	}{
+		{"query matches tags", model.SearchCriteria{Query: "typescript"}, []string{"Frontend Developer"}},
+		{"query matches whole words", model.SearchCriteria{Query: "go"}, []string{"Senior Go Engineer"}},
+		{"region", model.SearchCriteria{Location: "Berlin, Germany"}, []string{"Senior Go Engineer"}},
+		{"region matches country", model.SearchCriteria{Location: "Austin, US"}, []string{"Senior Go Engineer", "Frontend Developer"}},
+		{"on-site finds nothing", model.SearchCriteria{Workplace: model.WorkplaceOnSite}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobs, err := Fetch(context.Background(), nil, srv.URL, scraper.Paging{}, tt.criteria)
+			require.NoError(t, err)
+
+			var titles []string
+			for _, job := range jobs {
+				titles = append(titles, job.Title)
+			}
+			assert.Equal(t, tt.want, titles)
+		})
+	}
+}
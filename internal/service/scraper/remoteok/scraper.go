@@ -0,0 +1,22 @@
+// Package remoteok reads the remote jobs listed on Remote OK through its public
+// JSON feed, without a browser.
+package remoteok
+
+import (
+	"context"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+)
+
+// NewScraper creates a scraper reading the Remote OK feed.
+func NewScraper(opts ...scraper.Option) *scraper.Scraper {
+	return scraper.NewScraper(scraper.Definition{
+		Name: sourceName,
+		Fetch: func(ctx context.Context, s scraper.Settings, criteria model.SearchCriteria) ([]model.Job, error) {
+			return Fetch(ctx, s.Client, s.BaseURL, s.Paging, criteria)
+		},
+		Unsupported: unsupported,
+		Defaults:    scraper.Settings{BaseURL: DefaultBaseURL},
+	}, opts...)
+}
@@ -0,0 +1,136 @@
+package remotive
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+)
+
+// DefaultBaseURL is Remotive's public API.
+const DefaultBaseURL = "https://remotive.com"
+
+const (
+	sourceName = "Remotive"
+
+	searchPath = "/api/remote-jobs"
+)
+
+type response struct {
+	Jobs []posting `json:"jobs"`
+}
+
+type posting struct {
+	ID                        int64    `json:"id"`
+	URL                       string   `json:"url"`
+	Title                     string   `json:"title"`
+	CompanyName               string   `json:"company_name"`
+	Category                  string   `json:"category"`
+	Tags                      []string `json:"tags"`
+	JobType                   string   `json:"job_type"`         // e.g. "full_time"
+	PublicationDate           string   `json:"publication_date"` // UTC, without a zone
+	CandidateRequiredLocation string   `json:"candidate_required_location"`
+	Salary                    string   `json:"salary"` // free text, e.g. "$100k - $120k"
+	Description               string   `json:"description"`
+}
+
+var jobTypes = map[string]string{
+	"full_time":  "Full-time",
+	"part_time":  "Part-time",
+	"contract":   "Contract",
+	"freelance":  "Freelance",
+	"internship": "Internship",
+}
+
+// Fetch searches Remotive for the query and keeps the jobs matching the other
+// criteria. Every job is remote, so searching for another workplace finds nothing.
+func Fetch(ctx context.Context, client *http.Client, baseURL string, paging scraper.Paging, criteria model.SearchCriteria) ([]model.Job, error) {
+	if criteria.Workplace != "" && criteria.Workplace != model.WorkplaceRemote {
+		return nil, nil
+	}
+
+	var resp response
+	if err := scraper.GetJSON(ctx, client, sourceName, searchURL(baseURL, criteria.Query, paging), &resp); err != nil {
+		return nil, err
+	}
+
+	collector := scraper.NewCollector(paging, jobKey)
+	now := time.Now()
+
+	var jobs []model.Job
+	for _, p := range resp.Jobs {
+		job := toJob(p)
+		if matches(criteria, job, now) {
+			jobs = append(jobs, job)
+		}
+	}
+	collector.Add(jobs)
+
+	return collector.Jobs(), nil
+}
+
+func searchURL(baseURL string, query string, paging scraper.Paging) string {
+	params := url.Values{}
+	if query != "" {
+		params.Set("search", query)
+	}
+	if paging.MaxResults > 0 {
+		params.Set("limit", strconv.Itoa(paging.MaxResults))
+	}
+
+	u := baseURL + searchPath
+	if len(params) > 0 {
+		u += "?" + params.Encode()
+	}
+	return u
+}
+
+func toJob(p posting) model.Job {
+	job := model.Job{
+		ID:             strconv.FormatInt(p.ID, 10),
+		Title:          strings.TrimSpace(p.Title),
+		Company:        strings.TrimSpace(p.CompanyName),
+		Location:       "Remote",
+		Url:            p.URL,
+		Source:         sourceName,
+		Salary:         strings.TrimSpace(p.Salary),
+		Description:    scraper.HTMLText(p.Description),
+		EmploymentType: jobTypes[p.JobType],
+		Department:     p.Category,
+		Workplace:      model.WorkplaceRemote,
+		Regions:        strings.TrimSpace(p.CandidateRequiredLocation),
+		Tags:           p.Tags,
+	}
+
+	if posted, err := time.Parse("2006-01-02T15:04:05", p.PublicationDate); err == nil {
+		job.PostedAt = posted
+	}
+
+	return job
+}
+
+// matches applies the criteria the API can't search by.
+func matches(criteria model.SearchCriteria, job model.Job, now time.Time) bool {
+	return scraper.MatchRegion(criteria.Location, job.Regions) &&
+		scraper.MatchPosted(criteria.PostedWithin, job.PostedAt, now)
+}
+
+// unsupported lists the criteria neither the API nor matches handle.
+func unsupported(c model.SearchCriteria) []string {
+	var names []string
+	for _, name := range c.Criteria() {
+		if name != model.CriterionWorkplace && name != model.CriterionPostedWithin {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func jobKey(job model.Job) string {
+	return job.ID
+}
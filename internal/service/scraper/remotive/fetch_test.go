@@ -0,0 +1,68 @@
+package remotive
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+	"github.com/brandoyts/job-aggr/internal/service/scraper/scrapertest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newServer serves the search fixture, checking the query is passed on
+func newServer(t *testing.T) *httptest.Server {
+	return scrapertest.NewServer(t, map[string]string{"/api/remote-jobs": "testdata/search.json"}, func(r *http.Request) {
+		assert.Equal(t, "golang", r.URL.Query().Get("search"))
+	})
+}
+
+// TestFetchMapsJobs tests that jobs are mapped along with their tags, salary and region
+func TestFetchMapsJobs(t *testing.T) {
+	srv := newServer(t)
+
+	jobs, err := Fetch(context.Background(), nil, srv.URL, scraper.Paging{}, model.SearchCriteria{Query: "golang"})
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+
+	assert.Equal(t, model.Job{
+		ID:             "1901234",
+		Title:          "Senior Golang Engineer",
+		Company:        "Acme",
+		Location:       "Remote",
+		Url:            "https://remotive.com/remote-jobs/software-dev/senior-golang-engineer-1901234",
+		Source:         "Remotive",
+		Salary:         "€80k - €100k",
+		Description:    "Join our platform team.",
+		EmploymentType: "Full-time",
+		Department:     "Software Development",
+		Workplace:      model.WorkplaceRemote,
+		Regions:        "Europe, UTC-1 to UTC+3",
+		Tags:           []string{"go", "aws", "kubernetes"},
+		PostedAt:       time.Date(2024, 5, 6, 10, 19, 43, 0, time.UTC),
+	}, jobs[0])
+
+	assert.Equal(t, "Contract", jobs[1].EmploymentType)
+}
+
+// TestFetchFilters tests the criteria applied after searching
+func TestFetchFilters(t *testing.T) {
+	srv := newServer(t)
+
+	jobs, err := Fetch(context.Background(), nil, srv.URL, scraper.Paging{}, model.SearchCriteria{Query: "golang", Location: "Lisbon, Europe"})
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "Acme", jobs[0].Company)
+
+	jobs, err = Fetch(context.Background(), nil, srv.URL, scraper.Paging{MaxResults: 1}, model.SearchCriteria{Query: "golang"})
+	require.NoError(t, err)
+	assert.Len(t, jobs, 1)
+
+	jobs, err = Fetch(context.Background(), nil, srv.URL, scraper.Paging{}, model.SearchCriteria{Query: "golang", Workplace: model.WorkplaceHybrid})
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+}
@@ -0,0 +1,21 @@
+package remotive
+
+import (
+	"github.com/brandoyts/job-aggr/internal/config"
+	"github.com/brandoyts/job-aggr/internal/service/aggregator"
+	"github.com/brandoyts/job-aggr/internal/service/browser"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+)
+
+func init() {
+	scraper.Register(scraper.Source{
+		Name:         "remotive",
+		Label:        sourceName,
+		Capabilities: scraper.Capabilities{Salary: true, RemoteFilter: true},
+		New: func(_ *browser.Pool, cfg config.Source) (aggregator.JobScraper, error) {
+			return NewScraper(scraper.ConfigOptions(cfg)...), nil
+		},
+		// Remote jobs only, which would be noise in most searches.
+		OptIn: true,
+	})
+}
@@ -0,0 +1,136 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/config"
+	"github.com/brandoyts/job-aggr/internal/model"
+)
+
+// DefaultTimeout bounds a whole search when neither the source nor the config sets a timeout.
+const DefaultTimeout = 60 * time.Second
+
+// Settings are the settings sources have in common. Each source reads those it uses:
+// browser sources have no use for Client, nor career pages for BaseURL.
+type Settings struct {
+	Client  *http.Client  // calls APIs, http.DefaultClient when nil
+	BaseURL string        // site or API searched
+	Timeout time.Duration // bounds a whole search, none when zero
+	Paging  Paging
+}
+
+// Option changes the settings of a Scraper.
+type Option func(*Settings)
+
+// WithTimeout bounds how long a search may take. Zero disables the timeout.
+func WithTimeout(d time.Duration) Option {
+	return func(s *Settings) {
+		s.Timeout = d
+	}
+}
+
+// WithMaxPages sets how many result pages are read at most.
+func WithMaxPages(n int) Option {
+	return func(s *Settings) {
+		s.Paging.MaxPages = n
+	}
+}
+
+// WithMaxResults caps the number of jobs returned. Zero means unlimited.
+func WithMaxResults(n int) Option {
+	return func(s *Settings) {
+		s.Paging.MaxResults = n
+	}
+}
+
+// WithBaseURL sets the site or API searched, e.g. a regional site. Defaults to the source's.
+func WithBaseURL(u string) Option {
+	return func(s *Settings) {
+		s.BaseURL = strings.TrimSuffix(u, "/")
+	}
+}
+
+// WithHTTPClient sets the client used to call APIs. Defaults to http.DefaultClient.
+func WithHTTPClient(c *http.Client) Option {
+	return func(s *Settings) {
+		s.Client = c
+	}
+}
+
+// ConfigOptions translates the settings of a source in the config file into options.
+// Unset settings keep the source's defaults.
+func ConfigOptions(cfg config.Source) []Option {
+	opts := []Option{WithMaxPages(cfg.MaxPages), WithMaxResults(cfg.MaxResults)}
+	if cfg.Timeout > 0 {
+		opts = append(opts, WithTimeout(cfg.Timeout))
+	}
+	if cfg.BaseURL != "" {
+		opts = append(opts, WithBaseURL(cfg.BaseURL))
+	}
+	return opts
+}
+
+// FetchFunc runs a search of a source with its settings. The timeout is already applied to ctx.
+type FetchFunc func(ctx context.Context, settings Settings, criteria model.SearchCriteria) ([]model.Job, error)
+
+// Definition is what a source supplies to build its Scraper.
+type Definition struct {
+	Name        string // shown to users, e.g. "Greenhouse"
+	Fetch       FetchFunc
+	Unsupported func(criteria model.SearchCriteria) []string // criteria the source can't filter on
+	Defaults    Settings                                     // before options apply; a zero Timeout is DefaultTimeout
+}
+
+// Scraper runs the searches of a source, bounded by its timeout. Sources with more
+// to offer, e.g. enrichment, embed it.
+type Scraper struct {
+	def      Definition
+	settings Settings
+}
+
+// NewScraper creates the scraper of the source defined by def.
+func NewScraper(def Definition, opts ...Option) *Scraper {
+	s := &Scraper{def: def, settings: def.Defaults}
+	if s.settings.Timeout == 0 {
+		s.settings.Timeout = DefaultTimeout
+	}
+	for _, opt := range opts {
+		opt(&s.settings)
+	}
+	return s
+}
+
+func (s *Scraper) Name() string {
+	return s.def.Name
+}
+
+// Settings returns the settings of the scraper, options applied.
+func (s *Scraper) Settings() Settings {
+	return s.settings
+}
+
+func (s *Scraper) Fetch(ctx context.Context, criteria model.SearchCriteria) ([]model.Job, error) {
+	ctx, cancel := s.Bound(ctx)
+	defer cancel()
+
+	return s.def.Fetch(ctx, s.settings, criteria)
+}
+
+// Unsupported returns the search criteria this source cannot filter on.
+func (s *Scraper) Unsupported(criteria model.SearchCriteria) []string {
+	if s.def.Unsupported == nil {
+		return nil
+	}
+	return s.def.Unsupported(criteria)
+}
+
+// Bound applies the scraper's timeout to ctx, for work done outside of Fetch.
+func (s *Scraper) Bound(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.settings.Timeout > 0 {
+		return context.WithTimeout(ctx, s.settings.Timeout)
+	}
+	return context.WithCancel(ctx)
+}
@@ -0,0 +1,48 @@
+package scraper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/config"
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestScraperSettings tests that options override the source's defaults
+func TestScraperSettings(t *testing.T) {
+	def := Definition{Name: "Test", Defaults: Settings{BaseURL: "https://example.com"}}
+
+	s := NewScraper(def)
+	assert.Equal(t, "Test", s.Name())
+	assert.Equal(t, Settings{BaseURL: "https://example.com", Timeout: DefaultTimeout}, s.Settings())
+	assert.Nil(t, s.Unsupported(model.SearchCriteria{Workplace: model.WorkplaceRemote}))
+
+	s = NewScraper(def, ConfigOptions(config.Source{BaseURL: "https://uk.example.com/", MaxPages: 2, MaxResults: 10, Timeout: time.Second})...)
+	assert.Equal(t, Settings{
+		BaseURL: "https://uk.example.com",
+		Timeout: time.Second,
+		Paging:  Paging{MaxPages: 2, MaxResults: 10},
+	}, s.Settings())
+
+	s = NewScraper(def, WithTimeout(0))
+	assert.Zero(t, s.Settings().Timeout, "a zero timeout disables it")
+}
+
+// TestScraperFetch tests that searches get the settings and are bounded by the timeout
+func TestScraperFetch(t *testing.T) {
+	var got Settings
+	s := NewScraper(Definition{
+		Fetch: func(ctx context.Context, settings Settings, criteria model.SearchCriteria) ([]model.Job, error) {
+			got = settings
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}, WithTimeout(10*time.Millisecond), WithMaxResults(5))
+
+	_, err := s.Fetch(context.Background(), model.SearchCriteria{})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 5, got.Paging.MaxResults)
+}
@@ -0,0 +1,46 @@
+// Package scrapertest serves the fixtures saved in testdata to the tests of
+// sources that call an API or read pages over HTTP.
+package scrapertest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// NewServer starts a server answering requests for the paths of files, e.g.
+// "/api/jobs", with the fixture file they map to, e.g. "testdata/jobs.json".
+// Other paths are answered with http.NotFound. "{{base}}" in a fixture is replaced
+// with the server's URL, for fixtures linking to each other.
+//
+// check, when not nil, is called with every request for a fixture, e.g. to assert
+// its query. The server is closed when the test ends.
+func NewServer(t *testing.T, files map[string]string, check func(r *http.Request)) *httptest.Server {
+	t.Helper()
+
+	fixtures := map[string]string{}
+	for path, file := range files {
+		b, err := os.ReadFile(file)
+		require.NoError(t, err)
+		fixtures[path] = string(b)
+	}
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fixture, ok := fixtures[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		if check != nil {
+			check(r)
+		}
+		w.Write([]byte(strings.ReplaceAll(fixture, "{{base}}", srv.URL)))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
@@ -0,0 +1,23 @@
+// Package sources registers every bundled source with the scraper registry.
+// Import it for its side effects:
+//
+//	import _ "github.com/brandoyts/job-aggr/internal/service/scraper/sources"
+//
+// A new source only has to register itself in its package's init function and be
+// listed here.
+package sources
+
+import (
+	_ "github.com/brandoyts/job-aggr/internal/service/scraper/ashby"
+	_ "github.com/brandoyts/job-aggr/internal/service/scraper/feeds"
+	_ "github.com/brandoyts/job-aggr/internal/service/scraper/greenhouse"
+	_ "github.com/brandoyts/job-aggr/internal/service/scraper/hackernews"
+	_ "github.com/brandoyts/job-aggr/internal/service/scraper/indeed"
+	_ "github.com/brandoyts/job-aggr/internal/service/scraper/jsonld"
+	_ "github.com/brandoyts/job-aggr/internal/service/scraper/lever"
+	_ "github.com/brandoyts/job-aggr/internal/service/scraper/linkedin"
+	_ "github.com/brandoyts/job-aggr/internal/service/scraper/remoteok"
+	_ "github.com/brandoyts/job-aggr/internal/service/scraper/remotive"
+	_ "github.com/brandoyts/job-aggr/internal/service/scraper/weworkremotely"
+	_ "github.com/brandoyts/job-aggr/internal/service/scraper/workable"
+)
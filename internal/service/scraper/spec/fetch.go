@@ -0,0 +1,153 @@
+package spec
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/browser"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// Search is a search run by Fetch.
+type Search struct {
+	BaseURL  string // site searched, the spec's base URL when empty
+	Paging   scraper.Paging
+	Criteria model.SearchCriteria
+	Params   url.Values // criteria translated by a built-in source, passed to search_url as .Params
+}
+
+// searchData is what the search_url template is executed with.
+type searchData struct {
+	model.SearchCriteria
+	Params string // URL-encoded
+	Page   int    // from 1
+	Offset int    // (Page - 1) * pagination.step
+}
+
+// Fetch runs a search of s in a browser page of pool, reading up to
+// search.Paging.Pages() pages of results the way the spec's pagination says.
+// It stops early when a page yields no new job. The search is bounded by ctx only.
+//
+// When a page after the first one fails, the jobs of the earlier pages are returned
+// along with the error.
+func Fetch(ctx context.Context, pool *browser.Pool, s *Spec, search Search) ([]model.Job, error) {
+	baseURL := strings.TrimSuffix(search.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = s.BaseURL
+	}
+
+	page, err := scraper.Page(ctx, pool, s.Label)
+	if err != nil {
+		return nil, err
+	}
+	defer pool.Put(page)
+
+	// Bind the page to the caller's context so cancellation and deadlines abort the scrape.
+	page = page.Context(ctx)
+
+	pages := search.Paging.Pages()
+	if s.Pagination.Mode == PaginationNone {
+		pages = 1
+	}
+
+	collector := scraper.NewCollector(search.Paging, jobKey)
+
+	for i := 0; i < pages && !collector.Full(); i++ {
+		jobs, err := s.fetchPage(page, baseURL, search, i)
+		if err != nil {
+			return collector.Jobs(), err
+		}
+
+		// Scrolled cards stay on the page, so only new ones count.
+		if collector.Add(jobs) == 0 {
+			break
+		}
+	}
+
+	return collector.Jobs(), nil
+}
+
+// fetchPage reads the i-th page of results, from 0.
+func (s *Spec) fetchPage(page *rod.Page, baseURL string, search Search, i int) ([]model.Job, error) {
+	if i == 0 || s.Pagination.Mode == PaginationURL {
+		u, err := s.searchURL(baseURL, search, i)
+		if err != nil {
+			return nil, scraper.NewError(s.Label, scraper.ErrNavigation, "search_url", err)
+		}
+		if err := scraper.Navigate(page, s.Label, u); err != nil {
+			return nil, err
+		}
+	} else if err := s.loadMore(page); err != nil {
+		return nil, err
+	}
+
+	html, err := page.HTML()
+	if err != nil {
+		return nil, scraper.NavigationError(s.Label, "reading the page", err)
+	}
+
+	return s.ParsePage([]byte(html), baseURL, time.Now())
+}
+
+// searchURL executes the search_url template for the i-th page of results, from 0.
+// Relative URLs are appended to baseURL.
+func (s *Spec) searchURL(baseURL string, search Search, i int) (string, error) {
+	var b strings.Builder
+
+	err := s.search.Execute(&b, searchData{
+		SearchCriteria: search.Criteria,
+		Params:         search.Params.Encode(),
+		Page:           i + 1,
+		Offset:         i * s.Pagination.Step,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if u, err := url.Parse(b.String()); err == nil && u.IsAbs() {
+		return u.String(), nil
+	}
+	return baseURL + b.String(), nil
+}
+
+// loadMore scrolls to the bottom of the results and clicks the "show more" button when it shows up.
+// Its errors are ErrNavigation or ErrNavigationTimeout errors.
+func (s *Spec) loadMore(page *rod.Page) error {
+	if _, err := page.Eval(`() => window.scrollTo(0, document.body.scrollHeight)`); err != nil {
+		return scraper.NavigationError(s.Label, "scrolling the results", err)
+	}
+
+	if err := page.WaitIdle(scraper.IdleTimeout); err != nil {
+		return scraper.NavigationError(s.Label, "loading more results", err)
+	}
+
+	if s.Pagination.More == "" {
+		return nil
+	}
+
+	if found, button, err := page.Has(s.Pagination.More); err == nil && found {
+		if visible, _ := button.Visible(); visible {
+			if err := button.Click(proto.InputMouseButtonLeft, 1); err != nil {
+				return scraper.NavigationError(s.Label, "clicking "+s.Pagination.More, err)
+			}
+			if err := page.WaitIdle(scraper.IdleTimeout); err != nil {
+				return scraper.NavigationError(s.Label, "loading more results", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// jobKey identifies a job by its ID, falling back to its link.
+func jobKey(job model.Job) string {
+	if job.ID != "" {
+		return job.ID
+	}
+	return job.Url
+}
@@ -0,0 +1,58 @@
+package spec
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/browser"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+	"github.com/go-rod/rod/lib/launcher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFetch tests running a spec in a headless browser against a saved results page.
+// It is skipped when no Chrome or Chromium is installed.
+func TestFetch(t *testing.T) {
+	bin, found := launcher.LookPath()
+	if !found || testing.Short() {
+		t.Skip("no browser to run the spec in")
+	}
+
+	queries := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search" {
+			http.NotFound(w, r)
+			return
+		}
+		select {
+		case queries <- r.URL.RawQuery:
+		default:
+		}
+		http.ServeFile(w, r, "testdata/results.html")
+	}))
+	defer server.Close()
+
+	opts := browser.DefaultOptions()
+	opts.Bin = bin
+	pool := browser.NewPool(opts)
+	defer pool.Close()
+
+	s, err := Load("testdata/example.yaml")
+	require.NoError(t, err)
+
+	jobs, err := Fetch(context.Background(), pool, s, Search{
+		BaseURL:  server.URL,
+		Paging:   scraper.Paging{MaxPages: 1},
+		Criteria: model.SearchCriteria{Query: "golang", Location: "Berlin"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "q=golang&l=Berlin&page=1&from=0", <-queries)
+	require.Len(t, jobs, 2)
+	assert.Equal(t, "a1", jobs[0].ID)
+	assert.Equal(t, server.URL+"/jobs/101?ref=search", jobs[0].Url)
+}
@@ -0,0 +1,162 @@
+package spec
+
+import (
+	"bytes"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/andybalholm/cascadia"
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+	"golang.org/x/net/html"
+)
+
+var bodySelector = cascadia.MustCompile("body")
+
+// ParsePage extracts the jobs of the cards of a results page. Relative links are
+// resolved against baseURL. Cards without a title are skipped.
+//
+// A page without cards is fine when its body has text, e.g. "no jobs found", but is an
+// ErrEmptyPage otherwise. Cards none of which has a title mean the markup changed:
+// that is an ErrSelectorNotFound.
+func (s *Spec) ParsePage(page []byte, baseURL string, now time.Time) ([]model.Job, error) {
+	doc, err := html.Parse(bytes.NewReader(page))
+	if err != nil {
+		return nil, scraper.NewError(s.Label, scraper.ErrInvalidResponse, "", err)
+	}
+
+	base, err := url.Parse(baseURL + "/")
+	if err != nil {
+		return nil, scraper.NewError(s.Label, scraper.ErrInvalidResponse, baseURL, err)
+	}
+
+	cards := cascadia.QueryAll(doc, s.cards)
+	if len(cards) == 0 {
+		if body := cascadia.Query(doc, bodySelector); body == nil || scraper.NodeText(body) == "" {
+			return nil, scraper.NewError(s.Label, scraper.ErrEmptyPage, "", nil)
+		}
+		return nil, nil
+	}
+
+	var jobs []model.Job
+	for _, card := range cards {
+		if job, ok := s.parseCard(card, base, now); ok {
+			jobs = append(jobs, job)
+		}
+	}
+
+	if len(jobs) == 0 {
+		return nil, scraper.NewError(s.Label, scraper.ErrSelectorNotFound, "title in "+s.Cards, nil)
+	}
+	return jobs, nil
+}
+
+func (s *Spec) parseCard(card *html.Node, base *url.URL, now time.Time) (model.Job, bool) {
+	values := map[string]string{}
+
+	// Fields taken from other fields come last, once those have their value.
+	for _, derived := range []bool{false, true} {
+		for _, field := range s.fields() {
+			if field.rule.derived() != derived {
+				continue
+			}
+
+			value := field.rule.extract(card, values)
+			if field.name == "url" && value != "" {
+				value = resolve(base, value)
+			}
+			values[field.name] = value
+		}
+	}
+
+	if values["title"] == "" {
+		return model.Job{}, false
+	}
+
+	return model.Job{
+		ID:       values["id"],
+		Title:    values["title"],
+		Company:  values["company"],
+		Location: values["location"],
+		Url:      values["url"],
+		Source:   s.Label,
+		Salary:   values["salary"],
+		PostedAt: scraper.ParsePosted(values["posted"], now),
+	}, true
+}
+
+// derived reports whether any alternative of the rule is taken from another field.
+func (r Rule) derived() bool {
+	for _, f := range r {
+		if f.From != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// extract returns the value of the first alternative that yields one.
+func (r Rule) extract(card *html.Node, values map[string]string) string {
+	for _, f := range r {
+		if value, ok := f.extract(card, values); ok {
+			return value
+		}
+	}
+	return ""
+}
+
+func (f Field) extract(card *html.Node, values map[string]string) (string, bool) {
+	var value string
+
+	if f.From != "" {
+		value = values[f.From]
+	} else {
+		el := card
+		if f.selector != nil {
+			if el = cascadia.Query(card, f.selector); el == nil {
+				return "", false
+			}
+		}
+
+		if f.Attr != "" {
+			attr, ok := scraper.NodeAttr(el, f.Attr)
+			if !ok {
+				return "", false
+			}
+			value = strings.TrimSpace(attr)
+		} else {
+			value = scraper.NodeText(el)
+		}
+	}
+
+	if f.Param != "" {
+		u, err := url.Parse(value)
+		if err != nil {
+			return "", false
+		}
+		value = u.Query().Get(f.Param)
+	}
+
+	if f.pattern != nil {
+		m := f.pattern.FindStringSubmatch(value)
+		if m == nil {
+			return "", false
+		}
+		value = m[0]
+		if len(m) > 1 {
+			value = m[1]
+		}
+	}
+
+	return value, value != ""
+}
+
+// resolve makes a link absolute. Links that aren't URLs are kept as they are.
+func resolve(base *url.URL, link string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return link
+	}
+	return base.ResolveReference(u).String()
+}
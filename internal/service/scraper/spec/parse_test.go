@@ -0,0 +1,60 @@
+package spec
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParsePage tests extracting the jobs of a results page
+func TestParsePage(t *testing.T) {
+	s, err := Load("testdata/example.yaml")
+	require.NoError(t, err)
+
+	page, err := os.ReadFile("testdata/results.html")
+	require.NoError(t, err)
+
+	jobs, err := s.ParsePage(page, "https://jobs.example.com", time.Now())
+	require.NoError(t, err)
+
+	assert.Equal(t, []model.Job{
+		{
+			ID:       "a1",
+			Title:    "Go Developer",
+			Company:  "Acme",
+			Location: "Berlin",
+			Url:      "https://jobs.example.com/jobs/101?ref=search",
+			Source:   "Example Jobs",
+			Salary:   "€70,000 a year",
+			PostedAt: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:       "202",
+			Title:    "Backend Engineer",
+			Location: "Remote",
+			Url:      "https://partner.example.net/jobs/202",
+			Source:   "Example Jobs",
+		},
+	}, jobs, "cards without a title and elements outside the results are skipped")
+}
+
+// TestParsePageErrors tests telling pages without results apart from broken ones
+func TestParsePageErrors(t *testing.T) {
+	s, err := Load("testdata/example.yaml")
+	require.NoError(t, err)
+
+	jobs, err := s.ParsePage([]byte(`<html><body><p>No jobs found.</p></body></html>`), s.BaseURL, time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+
+	_, err = s.ParsePage([]byte(`<html><body> </body></html>`), s.BaseURL, time.Now())
+	assert.ErrorIs(t, err, scraper.ErrEmptyPage)
+
+	_, err = s.ParsePage([]byte(`<ul class="results"><li class="job"><h3>Renamed</h3></li></ul>`), s.BaseURL, time.Now())
+	assert.ErrorIs(t, err, scraper.ErrSelectorNotFound)
+}
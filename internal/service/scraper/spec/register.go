@@ -0,0 +1,63 @@
+package spec
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/brandoyts/job-aggr/internal/config"
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/aggregator"
+	"github.com/brandoyts/job-aggr/internal/service/browser"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+)
+
+// Register makes the spec available as a source. Unlike scraper.Register, it
+// returns an error when the name is already taken, as spec files come from users.
+func Register(s *Spec) error {
+	if _, dup := scraper.Lookup(s.Name); dup {
+		return fmt.Errorf("spec %s: source %q already exists", s.Name, s.Name)
+	}
+
+	scraper.Register(scraper.Source{
+		Name:         s.Name,
+		Label:        s.Label,
+		Capabilities: s.Capabilities(),
+		New: func(pool *browser.Pool, cfg config.Source) (aggregator.JobScraper, error) {
+			return NewScraper(pool, s, scraper.ConfigOptions(cfg)...), nil
+		},
+	})
+	return nil
+}
+
+// RegisterDir loads the spec files of dir and registers them as sources.
+// Nothing is registered when one of them is invalid.
+func RegisterDir(dir string) error {
+	specs, err := LoadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	seen := map[string]bool{}
+	for _, s := range specs {
+		if _, dup := scraper.Lookup(s.Name); dup || seen[s.Name] {
+			return fmt.Errorf("spec %s: source %q already exists", s.Name, s.Name)
+		}
+		seen[s.Name] = true
+	}
+
+	for _, s := range specs {
+		if err := Register(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Capabilities tells what the source described by the spec supports.
+func (s *Spec) Capabilities() scraper.Capabilities {
+	return scraper.Capabilities{
+		Pagination:   s.Pagination.Mode != PaginationNone,
+		Salary:       len(s.Fields.Salary) > 0,
+		RemoteFilter: slices.Contains(s.Filters, model.CriterionWorkplace),
+	}
+}
@@ -0,0 +1,22 @@
+package spec
+
+import (
+	"context"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/browser"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+)
+
+// NewScraper creates a scraper searching the job board described by spec.
+// scraper.WithBaseURL can pick another site than the spec's, e.g. a regional one.
+func NewScraper(pool *browser.Pool, spec *Spec, opts ...scraper.Option) *scraper.Scraper {
+	return scraper.NewScraper(scraper.Definition{
+		Name: spec.Label,
+		Fetch: func(ctx context.Context, s scraper.Settings, criteria model.SearchCriteria) ([]model.Job, error) {
+			return Fetch(ctx, pool, spec, Search{BaseURL: s.BaseURL, Paging: s.Paging, Criteria: criteria})
+		},
+		Unsupported: spec.Unsupported,
+		Defaults:    scraper.Settings{BaseURL: spec.BaseURL},
+	}, opts...)
+}
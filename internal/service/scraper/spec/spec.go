@@ -0,0 +1,373 @@
+// Package spec runs scrapers described by declarative specs rather than code: where
+// to search, which elements of the results page are job cards, and where each field
+// of a job is found in a card. Specs are YAML files; the bundled Indeed and LinkedIn
+// scrapers are specs too.
+//
+// A spec looks like:
+//
+//	name: example
+//	label: Example Jobs
+//	base_url: https://jobs.example.com
+//	search_url: /search?q={{urlquery .Query}}&l={{urlquery .Location}}&page={{.Page}}
+//	cards: li.job
+//	fields:
+//	  title: h2
+//	  company: .company
+//	  url: {selector: a, attr: href}
+//	  id: {field: url, pattern: '/jobs/(\d+)'}
+//	pagination:
+//	  mode: url
+package spec
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/andybalholm/cascadia"
+	"github.com/brandoyts/job-aggr/internal/model"
+	"gopkg.in/yaml.v3"
+)
+
+// Pagination modes.
+const (
+	PaginationNone   = "none"   // only the first page is read
+	PaginationURL    = "url"    // pages are opened with the next .Page and .Offset of search_url
+	PaginationScroll = "scroll" // more cards are loaded by scrolling the results page
+)
+
+var (
+	namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+	paginationModes = []string{PaginationNone, PaginationURL, PaginationScroll}
+
+	// filters are the criteria a search_url template can honor.
+	filters = []string{
+		model.CriterionWorkplace,
+		model.CriterionRadius,
+		model.CriterionPostedWithin,
+		model.CriterionJobType,
+		model.CriterionExperience,
+		model.CriterionMinSalary,
+		model.CriterionSort,
+	}
+)
+
+// Spec describes a job board scraped with a browser.
+type Spec struct {
+	Name    string `yaml:"name"`     // used in config files and on the command line
+	Label   string `yaml:"label"`    // shown to users, Name when empty
+	BaseURL string `yaml:"base_url"` // site searched, against which relative links are resolved
+
+	// SearchURL is a text/template of the results page URL, relative to BaseURL.
+	// It is executed with the search criteria, e.g. {{.Query}}, and .Page (from 1)
+	// and .Offset ((.Page - 1) * pagination.step). Built-in sources also pass the
+	// criteria translated into URL parameters as .Params.
+	SearchURL string `yaml:"search_url"`
+
+	// Filters are the optional criteria SearchURL honors, e.g. "workplace".
+	// Others are reported as unsupported.
+	Filters []string `yaml:"filters"`
+
+	Cards      string     `yaml:"cards"` // selector of the job cards on the results page
+	Fields     Fields     `yaml:"fields"`
+	Pagination Pagination `yaml:"pagination"`
+
+	search *template.Template
+	cards  cascadia.Selector
+}
+
+// Fields tells where each field of a job is found in its card. Only the title is required.
+type Fields struct {
+	ID       Rule `yaml:"id"`
+	Title    Rule `yaml:"title"`
+	Company  Rule `yaml:"company"`
+	Location Rule `yaml:"location"`
+	URL      Rule `yaml:"url"` // resolved against the base URL
+	Salary   Rule `yaml:"salary"`
+	Posted   Rule `yaml:"posted"` // absolute or relative date, e.g. "3 days ago"
+}
+
+// Pagination tells how more results are read.
+type Pagination struct {
+	Mode string `yaml:"mode"` // one of the Pagination modes, PaginationNone when empty
+	Step int    `yaml:"step"` // results per page, by which .Offset grows; 1 when zero
+	More string `yaml:"more"` // selector of a "show more" button clicked after scrolling
+}
+
+// Rule extracts a field from a card. It is a list of alternatives, the first one
+// yielding a value winning. A single Field can be given instead of a list.
+type Rule []Field
+
+// Field extracts a value from a card, or from another field. A plain string is a selector.
+type Field struct {
+	Selector string `yaml:"selector"` // element of the card whose text is used, the card itself when empty
+	Attr     string `yaml:"attr"`     // attribute used instead of the text, e.g. "href"
+	From     string `yaml:"field"`    // field whose value is used instead of an element, e.g. "url"
+	Param    string `yaml:"param"`    // query parameter of the value, which is a URL, e.g. "jk"
+	Pattern  string `yaml:"pattern"`  // regular expression the value must match; its first group is kept if any
+
+	selector cascadia.Selector
+	pattern  *regexp.Regexp
+}
+
+// UnmarshalYAML accepts a single field or a list of them.
+func (r *Rule) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.SequenceNode {
+		var fields []Field
+		if err := node.Decode(&fields); err != nil {
+			return err
+		}
+		*r = fields
+		return nil
+	}
+
+	var f Field
+	if err := node.Decode(&f); err != nil {
+		return err
+	}
+	*r = Rule{f}
+	return nil
+}
+
+// UnmarshalYAML accepts a selector alone as a shorthand.
+func (f *Field) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&f.Selector)
+	}
+
+	// Decode through another type, or this method would be called again.
+	type plain Field
+	return node.Decode((*plain)(f))
+}
+
+// Parse decodes and validates a YAML spec. Unknown settings are rejected so typos
+// don't go unnoticed.
+func Parse(b []byte) (*Spec, error) {
+	var s Spec
+
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	dec.KnownFields(true)
+
+	if err := dec.Decode(&s); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("empty spec")
+		}
+		return nil, err
+	}
+
+	if err := s.compile(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// MustParse is like Parse but panics when the spec is invalid. It is meant for
+// the specs bundled with the program.
+func MustParse(b []byte) *Spec {
+	s, err := Parse(b)
+	if err != nil {
+		panic("spec: " + err.Error())
+	}
+	return s
+}
+
+// Load reads the spec file at path.
+func Load(path string) (*Spec, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("spec: %w", err)
+	}
+
+	s, err := Parse(b)
+	if err != nil {
+		return nil, fmt.Errorf("spec %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// LoadDir reads every .yaml and .yml spec file of dir, sorted by file name.
+// A missing directory holds no specs.
+func LoadDir(dir string) ([]*Spec, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("spec: %w", err)
+	}
+
+	var specs []*Spec
+	for _, e := range entries {
+		if ext := filepath.Ext(e.Name()); e.IsDir() || ext != ".yaml" && ext != ".yml" {
+			continue
+		}
+
+		s, err := Load(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, s)
+	}
+	return specs, nil
+}
+
+// compile validates the spec, fills in its defaults and compiles its selectors,
+// patterns and template. Every problem is reported, one per line.
+func (s *Spec) compile() error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if !namePattern.MatchString(s.Name) {
+		fail("name: %q must be lowercase letters, digits and dashes", s.Name)
+	}
+	if s.Label == "" {
+		s.Label = s.Name
+	}
+	s.BaseURL = strings.TrimSuffix(s.BaseURL, "/")
+	if u, err := url.Parse(s.BaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		fail("base_url: %q is not an http(s) URL", s.BaseURL)
+	}
+
+	if s.SearchURL == "" {
+		fail("search_url: missing")
+	} else if t, err := template.New(s.Name).Option("missingkey=error").Parse(s.SearchURL); err != nil {
+		fail("search_url: %v", err)
+	} else if err := t.Execute(io.Discard, searchData{}); err != nil {
+		fail("search_url: %v", err)
+	} else {
+		s.search = t
+	}
+
+	for i, f := range s.Filters {
+		if !slices.Contains(filters, f) {
+			fail("filters[%d]: %q is not one of %s", i, f, strings.Join(filters, ", "))
+		}
+	}
+
+	if s.Cards == "" {
+		fail("cards: missing")
+	} else if sel, err := cascadia.Compile(s.Cards); err != nil {
+		fail("cards: %v", err)
+	} else {
+		s.cards = sel
+	}
+
+	if len(s.Fields.Title) == 0 {
+		fail("fields.title: missing")
+	}
+	for _, field := range s.fields() {
+		for i := range *field.rule {
+			f := &(*field.rule)[i]
+			if err := f.compile(s); err != nil {
+				fail("fields.%s[%d]: %v", field.name, i, err)
+			}
+		}
+	}
+
+	p := &s.Pagination
+	if p.Mode == "" {
+		p.Mode = PaginationNone
+	}
+	if !slices.Contains(paginationModes, p.Mode) {
+		fail("pagination.mode: %q is not one of %s", p.Mode, strings.Join(paginationModes, ", "))
+	}
+	if p.Step < 0 {
+		fail("pagination.step: must not be negative")
+	}
+	if p.Step == 0 {
+		p.Step = 1
+	}
+	if p.More != "" {
+		if p.Mode != PaginationScroll {
+			fail("pagination.more: only used when scrolling")
+		} else if _, err := cascadia.Compile(p.More); err != nil {
+			fail("pagination.more: %v", err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (f *Field) compile(s *Spec) error {
+	if f.From != "" {
+		if f.Selector != "" || f.Attr != "" {
+			return errors.New("field can't be combined with selector or attr")
+		}
+		rule, ok := s.field(f.From)
+		if !ok {
+			return fmt.Errorf("field: unknown field %q", f.From)
+		}
+		for _, other := range rule {
+			if other.From != "" {
+				return fmt.Errorf("field: %q is itself taken from another field", f.From)
+			}
+		}
+	}
+
+	if f.Selector != "" {
+		sel, err := cascadia.Compile(f.Selector)
+		if err != nil {
+			return err
+		}
+		f.selector = sel
+	}
+
+	if f.Pattern != "" {
+		re, err := regexp.Compile(f.Pattern)
+		if err != nil {
+			return fmt.Errorf("pattern: %v", err)
+		}
+		f.pattern = re
+	}
+
+	return nil
+}
+
+type namedRule struct {
+	name string
+	rule *Rule
+}
+
+// fields lists the rules of the spec with their names in the YAML file.
+func (s *Spec) fields() []namedRule {
+	return []namedRule{
+		{"id", &s.Fields.ID},
+		{"title", &s.Fields.Title},
+		{"company", &s.Fields.Company},
+		{"location", &s.Fields.Location},
+		{"url", &s.Fields.URL},
+		{"salary", &s.Fields.Salary},
+		{"posted", &s.Fields.Posted},
+	}
+}
+
+func (s *Spec) field(name string) (Rule, bool) {
+	for _, f := range s.fields() {
+		if f.name == name {
+			return *f.rule, true
+		}
+	}
+	return nil, false
+}
+
+// Unsupported returns the criteria that are set but not among the spec's filters.
+func (s *Spec) Unsupported(criteria model.SearchCriteria) []string {
+	var unsupported []string
+	for _, c := range criteria.Criteria() {
+		if !slices.Contains(s.Filters, c) {
+			unsupported = append(unsupported, c)
+		}
+	}
+	return unsupported
+}
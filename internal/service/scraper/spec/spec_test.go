@@ -0,0 +1,147 @@
+package spec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/config"
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLoad tests loading a spec file, with its shorthands and defaults
+func TestLoad(t *testing.T) {
+	s, err := Load("testdata/example.yaml")
+	require.NoError(t, err)
+
+	assert.Equal(t, "example", s.Name)
+	assert.Equal(t, "Example Jobs", s.Label)
+	assert.Equal(t, "https://jobs.example.com", s.BaseURL)
+	assert.Equal(t, "h2", s.Fields.Title[0].Selector)
+	assert.Len(t, s.Fields.Location, 2)
+	assert.Equal(t, "url", s.Fields.ID[1].From)
+	assert.Equal(t, PaginationURL, s.Pagination.Mode)
+
+	assert.Equal(t, scraper.Capabilities{Pagination: true, Salary: true, RemoteFilter: true}, s.Capabilities())
+	assert.Equal(t, []string{model.CriterionPostedWithin}, s.Unsupported(model.SearchCriteria{
+		Workplace:    model.WorkplaceRemote,
+		PostedWithin: 24 * time.Hour,
+	}))
+}
+
+// TestParseDefaults tests the defaults of a minimal spec
+func TestParseDefaults(t *testing.T) {
+	s, err := Parse([]byte("name: minimal\nbase_url: https://example.com\nsearch_url: /jobs\ncards: li\nfields:\n  title: h2\n"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "minimal", s.Label)
+	assert.Equal(t, Pagination{Mode: PaginationNone, Step: 1}, s.Pagination)
+	assert.Equal(t, scraper.Capabilities{}, s.Capabilities())
+}
+
+// TestParseErrors tests that every problem of a spec is reported
+func TestParseErrors(t *testing.T) {
+	_, err := Parse([]byte(`
+name: Example
+base_url: jobs.example.com
+search_url: "/search?q={{.Keywords}}"
+filters: [remote]
+cards: "li:first-child >"
+fields:
+  company: {selector: .company, field: url}
+  url: {selector: "a[href", attr: href}
+  id: {field: salary, pattern: "(\\d+"}
+  posted: {field: id}
+pagination:
+  mode: infinite
+  step: -1
+  more: button.more
+`))
+	require.Error(t, err)
+
+	for _, want := range []string{
+		`name: "Example" must be lowercase letters, digits and dashes`,
+		`base_url: "jobs.example.com" is not an http(s) URL`,
+		"can't evaluate field Keywords",
+		`filters[0]: "remote" is not one of workplace, radius`,
+		"cards: expected selector, found EOF instead",
+		"fields.title: missing",
+		"fields.company[0]: field can't be combined with selector or attr",
+		"fields.url[0]: unexpected EOF in attribute selector",
+		"fields.id[0]: pattern: error parsing regexp",
+		`fields.posted[0]: field: "id" is itself taken from another field`,
+		`pagination.mode: "infinite" is not one of none, url, scroll`,
+		"pagination.step: must not be negative",
+		"pagination.more: only used when scrolling",
+	} {
+		assert.ErrorContains(t, err, want)
+	}
+
+	_, err = Parse([]byte("name: x\ntitle: h2\n"))
+	assert.ErrorContains(t, err, "field title not found", "unknown settings are rejected")
+
+	_, err = Parse(nil)
+	assert.EqualError(t, err, "empty spec")
+}
+
+// TestLoadDir tests that every spec file of a directory is loaded
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	example, err := os.ReadFile("testdata/example.yaml")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "example.yml"), example, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not a spec"), 0o644))
+
+	specs, err := LoadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, specs, 1)
+	assert.Equal(t, "example", specs[0].Name)
+
+	specs, err = LoadDir(filepath.Join(dir, "missing"))
+	require.NoError(t, err)
+	assert.Empty(t, specs)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.yaml"), []byte("name: broken\n"), 0o644))
+	_, err = LoadDir(dir)
+	assert.ErrorContains(t, err, "broken.yaml: base_url")
+}
+
+// TestRegisterDir tests that spec files become sources, and can't take the name of another one
+func TestRegisterDir(t *testing.T) {
+	dir := t.TempDir()
+	spec := "name: test-spec-jobs\nlabel: Spec Jobs\nbase_url: https://example.com\nsearch_url: /jobs\ncards: li\nfields:\n  title: h2\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "jobs.yaml"), []byte(spec), 0o644))
+
+	require.NoError(t, RegisterDir(dir))
+
+	src, ok := scraper.Lookup("test-spec-jobs")
+	require.True(t, ok)
+	assert.Equal(t, "Spec Jobs", src.Label)
+
+	s, err := scraper.New("test-spec-jobs", nil, config.Source{BaseURL: "https://uk.example.com/"})
+	require.NoError(t, err)
+	assert.Equal(t, "Spec Jobs", s.(*scraper.Scraper).Name())
+	assert.Equal(t, "https://uk.example.com", s.(*scraper.Scraper).Settings().BaseURL)
+
+	assert.ErrorContains(t, RegisterDir(dir), `source "test-spec-jobs" already exists`)
+}
+
+// TestSearchURL tests executing the search URL template of each page
+func TestSearchURL(t *testing.T) {
+	s, err := Load("testdata/example.yaml")
+	require.NoError(t, err)
+
+	search := Search{Criteria: model.SearchCriteria{Query: "go developer", Location: "Berlin", Workplace: model.WorkplaceRemote}}
+
+	u, err := s.searchURL(s.BaseURL, search, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "https://jobs.example.com/search?q=go+developer&l=Berlin&remote=1&page=1&from=0", u)
+
+	u, err = s.searchURL("https://jobs.example.de", search, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "https://jobs.example.de/search?q=go+developer&l=Berlin&remote=1&page=3&from=40", u)
+}
@@ -0,0 +1,59 @@
+// Package spectest checks scraper specs against results pages saved in testdata,
+// comparing the jobs they yield with golden files. Run the tests with -update to
+// rewrite the golden files after a deliberate change.
+package spectest
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/scraper/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files of scraper specs")
+
+// Now is the time relative posting dates, e.g. "3 days ago", are counted from.
+var Now = time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+
+// Parse parses testdata/<name>.html with s, resolving relative links against baseURL.
+func Parse(t *testing.T, s *spec.Spec, baseURL string, name string) ([]model.Job, error) {
+	t.Helper()
+
+	page, err := os.ReadFile(filepath.Join("testdata", name+".html"))
+	require.NoError(t, err)
+
+	return s.ParsePage(page, baseURL, Now)
+}
+
+// Golden parses testdata/<name>.html with s and compares the jobs with
+// testdata/<name>.golden.json.
+func Golden(t *testing.T, s *spec.Spec, baseURL string, name string) {
+	t.Helper()
+
+	jobs, err := Parse(t, s, baseURL, name)
+	require.NoError(t, err)
+
+	// Links are kept readable rather than escaped for HTML.
+	var got bytes.Buffer
+	enc := json.NewEncoder(&got)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	require.NoError(t, enc.Encode(jobs))
+
+	path := filepath.Join("testdata", name+".golden.json")
+	if *update {
+		require.NoError(t, os.WriteFile(path, got.Bytes(), 0o644))
+	}
+
+	want, err := os.ReadFile(path)
+	require.NoError(t, err, "run the test with -update to create the golden file")
+	assert.JSONEq(t, string(want), got.String())
+}
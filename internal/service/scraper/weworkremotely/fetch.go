@@ -0,0 +1,103 @@
+package weworkremotely
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+)
+
+// DefaultBaseURL is the We Work Remotely site.
+const DefaultBaseURL = "https://weworkremotely.com"
+
+const (
+	sourceName = "We Work Remotely"
+
+	// feedPath lists the latest jobs of every category.
+	feedPath = "/remote-jobs.rss"
+)
+
+// Fetch reads the feed and keeps the jobs matching the criteria. Every job is
+// remote, so searching for another workplace finds nothing.
+func Fetch(ctx context.Context, client *http.Client, baseURL string, paging scraper.Paging, criteria model.SearchCriteria) ([]model.Job, error) {
+	if criteria.Workplace != "" && criteria.Workplace != model.WorkplaceRemote {
+		return nil, nil
+	}
+
+	u := baseURL + feedPath
+	body, err := scraper.Get(ctx, client, sourceName, u)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := scraper.ParseFeed(body)
+	if err != nil {
+		return nil, scraper.NewError(sourceName, scraper.ErrInvalidResponse, u, err)
+	}
+
+	collector := scraper.NewCollector(paging, jobKey)
+	now := time.Now()
+
+	var jobs []model.Job
+	for _, item := range items {
+		job := toJob(item)
+		if matches(criteria, job, now) {
+			jobs = append(jobs, job)
+		}
+	}
+	collector.Add(jobs)
+
+	return collector.Jobs(), nil
+}
+
+// toJob maps a feed item. Titles name the company first, e.g. "Acme: Senior Go Engineer";
+// the region and employment type are elements of their own.
+func toJob(item scraper.FeedItem) model.Job {
+	company, title, ok := strings.Cut(item.Title, ": ")
+	if !ok {
+		company, title = "", item.Title
+	}
+
+	return model.Job{
+		ID:             item.GUID,
+		Title:          strings.TrimSpace(title),
+		Company:        strings.TrimSpace(company),
+		Location:       "Remote",
+		Url:            item.Link,
+		Source:         sourceName,
+		Description:    scraper.HTMLText(item.Description),
+		EmploymentType: item.Extra["type"],
+		Workplace:      model.WorkplaceRemote,
+		Regions:        item.Extra["region"],
+		Tags:           item.Categories,
+		PostedAt:       item.Published,
+	}
+}
+
+// matches filters the feed client-side, since it lists every recent job.
+func matches(criteria model.SearchCriteria, job model.Job, now time.Time) bool {
+	return scraper.MatchQuery(criteria.Query, job.Title, strings.Join(job.Tags, " ")) &&
+		scraper.MatchRegion(criteria.Location, job.Regions) &&
+		scraper.MatchPosted(criteria.PostedWithin, job.PostedAt, now)
+}
+
+// unsupported lists the criteria matches ignores.
+func unsupported(c model.SearchCriteria) []string {
+	var names []string
+	for _, name := range c.Criteria() {
+		if name != model.CriterionWorkplace && name != model.CriterionPostedWithin {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func jobKey(job model.Job) string {
+	if job.ID != "" {
+		return job.ID
+	}
+	return job.Url
+}
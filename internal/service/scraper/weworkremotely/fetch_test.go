@@ -0,0 +1,82 @@
+package weworkremotely
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+	"github.com/brandoyts/job-aggr/internal/service/scraper/scrapertest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newServer serves the feed fixture, and a maintenance page in its place under /maintenance
+func newServer(t *testing.T) *httptest.Server {
+	return scrapertest.NewServer(t, map[string]string{
+		"/remote-jobs.rss":             "testdata/feed.rss",
+		"/maintenance/remote-jobs.rss": "testdata/maintenance.html",
+	}, nil)
+}
+
+// TestFetchMapsJobs tests that feed items are mapped along with their category, region and type
+func TestFetchMapsJobs(t *testing.T) {
+	srv := newServer(t)
+
+	jobs, err := Fetch(context.Background(), nil, srv.URL, scraper.Paging{}, model.SearchCriteria{})
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+
+	assert.Equal(t, model.Job{
+		ID:             "https://weworkremotely.com/remote-jobs/acme-senior-go-engineer",
+		Title:          "Senior Go Engineer",
+		Company:        "Acme",
+		Location:       "Remote",
+		Url:            "https://weworkremotely.com/remote-jobs/acme-senior-go-engineer",
+		Source:         "We Work Remotely",
+		Description:    "Headquarters: Berlin\nBuild our API in Go.",
+		EmploymentType: "Full-Time",
+		Workplace:      model.WorkplaceRemote,
+		Regions:        "Anywhere in the World",
+		Tags:           []string{"Back-End Programming"},
+		PostedAt:       time.Date(2024, 5, 6, 14, 5, 12, 0, time.UTC),
+	}, jobs[0])
+}
+
+// TestFetchFilters tests that the feed is filtered by the search criteria
+func TestFetchFilters(t *testing.T) {
+	srv := newServer(t)
+
+	tests := []struct {
+		name     string
+		criteria model.SearchCriteria
+		want     []string
+	}{
+		{"query matches category", model.SearchCriteria{Query: "back-end"}, []string{"Senior Go Engineer"}},
+		{"region", model.SearchCriteria{Location: "Denver, USA"}, []string{"Senior Go Engineer", "Customer Support Lead"}},
+		{"restricted region", model.SearchCriteria{Location: "Toronto, Canada"}, []string{"Senior Go Engineer"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobs, err := Fetch(context.Background(), nil, srv.URL, scraper.Paging{}, tt.criteria)
+			require.NoError(t, err)
+
+			var titles []string
+			for _, job := range jobs {
+				titles = append(titles, job.Title)
+			}
+			assert.Equal(t, tt.want, titles)
+		})
+	}
+}
+
+// TestFetchInvalidFeed tests that a page that isn't a feed is reported
+func TestFetchInvalidFeed(t *testing.T) {
+	srv := newServer(t)
+
+	_, err := Fetch(context.Background(), nil, srv.URL+"/maintenance", scraper.Paging{}, model.SearchCriteria{})
+	assert.ErrorIs(t, err, scraper.ErrInvalidResponse)
+}
@@ -0,0 +1,169 @@
+package workable
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+)
+
+// DefaultBaseURL is Workable's public careers API.
+const DefaultBaseURL = "https://apply.workable.com"
+
+const (
+	sourceName = "Workable"
+
+	// accountPath lists every published job of an account, with its description.
+	accountPath = "/api/v1/widget/accounts/%s?details=true"
+)
+
+// account is the response of the widget endpoint.
+type account struct {
+	Name string    `json:"name"`
+	Jobs []posting `json:"jobs"`
+}
+
+type posting struct {
+	Title          string `json:"title"`
+	Shortcode      string `json:"shortcode"`
+	EmploymentType string `json:"employment_type"`
+	Telecommuting  bool   `json:"telecommuting"`
+	Department     string `json:"department"`
+	URL            string `json:"url"`
+	ApplicationURL string `json:"application_url"`
+	PublishedOn    string `json:"published_on"` // e.g. "2024-05-08"
+	Description    string `json:"description"`  // HTML
+	City           string `json:"city"`
+	State          string `json:"state"`
+	Country        string `json:"country"`
+	Locations      []struct {
+		City    string `json:"city"`
+		Region  string `json:"region"`
+		Country string `json:"country"`
+		Hidden  bool   `json:"hidden"`
+	} `json:"locations"`
+	// Salary is only published by accounts that opted into pay transparency.
+	Salary *struct {
+		From     float64 `json:"salary_from"`
+		To       float64 `json:"salary_to"`
+		Currency string  `json:"salary_currency"`
+	} `json:"salary"`
+}
+
+// Fetch reads every account and keeps the jobs matching the criteria.
+func Fetch(ctx context.Context, client *http.Client, baseURL string, accounts []string, paging scraper.Paging, criteria model.SearchCriteria) ([]model.Job, error) {
+	now := time.Now()
+
+	return scraper.FetchCompanies(ctx, sourceName, accounts, paging, jobKey, func(ctx context.Context, subdomain string) ([]model.Job, error) {
+		var a account
+		if err := scraper.GetJSON(ctx, client, sourceName, baseURL+fmt.Sprintf(accountPath, url.PathEscape(subdomain)), &a); err != nil {
+			return nil, err
+		}
+
+		company := a.Name
+		if company == "" {
+			company = subdomain
+		}
+
+		var jobs []model.Job
+		for _, p := range a.Jobs {
+			job := toJob(company, p)
+			if matches(criteria, job, locations(p), now) {
+				jobs = append(jobs, job)
+			}
+		}
+		return jobs, nil
+	})
+}
+
+func toJob(company string, p posting) model.Job {
+	job := model.Job{
+		ID:             p.Shortcode,
+		Title:          p.Title,
+		Company:        company,
+		Location:       joinPlace(p.City, p.State, p.Country),
+		Url:            p.URL,
+		ApplyUrl:       p.ApplicationURL,
+		Source:         sourceName,
+		Description:    scraper.HTMLText(p.Description),
+		EmploymentType: p.EmploymentType,
+		Department:     p.Department,
+	}
+
+	// Jobs that aren't remote may still be hybrid, which the widget doesn't tell.
+	if p.Telecommuting {
+		job.Workplace = model.WorkplaceRemote
+	}
+
+	if posted, err := time.Parse(time.DateOnly, p.PublishedOn); err == nil {
+		job.PostedAt = posted
+	}
+
+	if s := p.Salary; s != nil && (s.From > 0 || s.To > 0) {
+		job.Pay = model.Salary{Min: s.From, Max: s.To, Currency: strings.ToUpper(s.Currency), Period: model.PeriodYear}
+		job.Salary = job.Pay.String()
+	}
+
+	return job
+}
+
+// locations lists every place the job is offered in, main location first.
+func locations(p posting) []string {
+	places := []string{joinPlace(p.City, p.State, p.Country)}
+	for _, l := range p.Locations {
+		if !l.Hidden {
+			places = append(places, joinPlace(l.City, l.Region, l.Country))
+		}
+	}
+	return places
+}
+
+// joinPlace formats a location, e.g. "Berlin, Germany", skipping empty parts.
+func joinPlace(parts ...string) string {
+	var nonEmpty []string
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	return strings.Join(nonEmpty, ", ")
+}
+
+// matches filters the account client-side, since the API returns every job of an account.
+func matches(criteria model.SearchCriteria, job model.Job, places []string, now time.Time) bool {
+	remote := job.Workplace == model.WorkplaceRemote
+
+	if criteria.Workplace == model.WorkplaceRemote && !remote {
+		return false
+	}
+
+	return scraper.MatchQuery(criteria.Query, job.Title, job.Department) &&
+		scraper.MatchLocation(criteria.Location, strings.Join(places, " / "), remote) &&
+		scraper.MatchPosted(criteria.PostedWithin, job.PostedAt, now)
+}
+
+// unsupported lists the criteria matches ignores. Only remote jobs can be told apart.
+func unsupported(c model.SearchCriteria) []string {
+	var names []string
+	for _, name := range c.Criteria() {
+		switch name {
+		case model.CriterionPostedWithin:
+		case model.CriterionWorkplace:
+			if c.Workplace != model.WorkplaceRemote {
+				names = append(names, name)
+			}
+		default:
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func jobKey(job model.Job) string {
+	return job.ID
+}
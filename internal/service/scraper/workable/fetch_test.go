@@ -0,0 +1,80 @@
+package workable
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+	"github.com/brandoyts/job-aggr/internal/service/scraper/scrapertest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newServer serves the account fixture for the "acme" account and fails every other one
+func newServer(t *testing.T) *httptest.Server {
+	return scrapertest.NewServer(t, map[string]string{"/api/v1/widget/accounts/acme": "testdata/account.json"}, func(r *http.Request) {
+		assert.Equal(t, "true", r.URL.Query().Get("details"))
+	})
+}
+
+// TestFetchMapsJobs tests that jobs are mapped along with their salary and remote flag
+func TestFetchMapsJobs(t *testing.T) {
+	srv := newServer(t)
+
+	jobs, err := Fetch(context.Background(), nil, srv.URL, []string{"acme"}, scraper.Paging{}, model.SearchCriteria{})
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+
+	assert.Equal(t, model.Job{
+		ID:             "A1B2C3D4E5",
+		Title:          "Embedded Go Developer",
+		Company:        "Acme Robotics",
+		Location:       "Munich, Bavaria, Germany",
+		Url:            "https://apply.workable.com/j/A1B2C3D4E5",
+		ApplyUrl:       "https://apply.workable.com/j/A1B2C3D4E5/apply",
+		Source:         "Workable",
+		Salary:         "EUR 65,000–80,000 / year",
+		Description:    "Program robots in Go.\nLinux",
+		EmploymentType: "Full-time",
+		Department:     "Engineering",
+		PostedAt:       time.Date(2024, 5, 8, 0, 0, 0, 0, time.UTC),
+		Pay:            model.Salary{Min: 65000, Max: 80000, Currency: "EUR", Period: model.PeriodYear},
+	}, jobs[0])
+
+	assert.Equal(t, model.WorkplaceRemote, jobs[1].Workplace)
+	assert.Equal(t, "Portugal", jobs[1].Location)
+	assert.Empty(t, jobs[1].Salary)
+}
+
+// TestFetchFilters tests that accounts are filtered by the search criteria
+func TestFetchFilters(t *testing.T) {
+	srv := newServer(t)
+
+	tests := []struct {
+		name     string
+		criteria model.SearchCriteria
+		want     []string
+	}{
+		{"query matches department", model.SearchCriteria{Query: "customer"}, []string{"Support Engineer"}},
+		{"other location", model.SearchCriteria{Location: "Vienna"}, []string{"Embedded Go Developer"}},
+		{"hidden location", model.SearchCriteria{Location: "Madrid"}, nil},
+		{"remote workplace", model.SearchCriteria{Workplace: model.WorkplaceRemote}, []string{"Support Engineer"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobs, err := Fetch(context.Background(), nil, srv.URL, []string{"acme"}, scraper.Paging{}, tt.criteria)
+			require.NoError(t, err)
+
+			var titles []string
+			for _, job := range jobs {
+				titles = append(titles, job.Title)
+			}
+			assert.Equal(t, tt.want, titles)
+		})
+	}
+}
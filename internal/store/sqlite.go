@@ -0,0 +1,655 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	_ "modernc.org/sqlite" // pure-Go SQLite driver
+)
+
+// migrations are applied in order; PRAGMA user_version records how many ran.
+var migrations = []string{
+	`CREATE TABLE jobs (
+		source          TEXT    NOT NULL,
+		job_key         TEXT    NOT NULL,
+		id              TEXT    NOT NULL DEFAULT '',
+		title           TEXT    NOT NULL DEFAULT '',
+		company         TEXT    NOT NULL DEFAULT '',
+		location        TEXT    NOT NULL DEFAULT '',
+		url             TEXT    NOT NULL DEFAULT '',
+		salary          TEXT    NOT NULL DEFAULT '',
+		description     TEXT    NOT NULL DEFAULT '',
+		employment_type TEXT    NOT NULL DEFAULT '',
+		posted_at       INTEGER,
+		pay             TEXT    NOT NULL DEFAULT '',
+		first_seen_at   INTEGER NOT NULL,
+		last_seen_at    INTEGER NOT NULL,
+		PRIMARY KEY (source, job_key)
+	);
+	CREATE TABLE searches (
+		id         INTEGER PRIMARY KEY,
+		criteria   TEXT    NOT NULL UNIQUE,
+		created_at INTEGER NOT NULL
+	);
+	CREATE TABLE search_jobs (
+		search_id     INTEGER NOT NULL REFERENCES searches(id),
+		source        TEXT    NOT NULL,
+		job_key       TEXT    NOT NULL,
+		first_seen_at INTEGER NOT NULL,
+		last_seen_at  INTEGER NOT NULL,
+		PRIMARY KEY (search_id, source, job_key),
+		FOREIGN KEY (source, job_key) REFERENCES jobs(source, job_key)
+	);
+	CREATE INDEX jobs_last_seen ON jobs(last_seen_at);`,
+
+	`CREATE TABLE runs (
+		id         INTEGER PRIMARY KEY,
+		search_id  INTEGER NOT NULL REFERENCES searches(id),
+		started_at INTEGER NOT NULL
+	);
+	CREATE TABLE run_jobs (
+		run_id  INTEGER NOT NULL REFERENCES runs(id),
+		source  TEXT    NOT NULL,
+		job_key TEXT    NOT NULL,
+		PRIMARY KEY (run_id, source, job_key),
+		FOREIGN KEY (source, job_key) REFERENCES jobs(source, job_key)
+	);
+	CREATE TABLE saved_searches (
+		name      TEXT    PRIMARY KEY,
+		search_id INTEGER NOT NULL REFERENCES searches(id),
+		criteria  TEXT    NOT NULL
+	);
+	CREATE INDEX runs_search ON runs(search_id, id);`,
+
+	`ALTER TABLE jobs ADD COLUMN department TEXT NOT NULL DEFAULT '';`,
+
+	`ALTER TABLE jobs ADD COLUMN workplace TEXT NOT NULL DEFAULT '';
+	ALTER TABLE jobs ADD COLUMN apply_url TEXT NOT NULL DEFAULT '';`,
+
+	`ALTER TABLE jobs ADD COLUMN expires_at INTEGER;`,
+
+	`ALTER TABLE jobs ADD COLUMN regions TEXT NOT NULL DEFAULT '';
+	ALTER TABLE jobs ADD COLUMN tags TEXT NOT NULL DEFAULT '';`,
+
+	// found_by is the source of the run that found the job, which may differ from the
+	// job's own, e.g. the feeds source finds jobs named after each feed. It is empty
+	// for runs recorded before, where both were the same.
+	`ALTER TABLE run_jobs ADD COLUMN found_by TEXT NOT NULL DEFAULT '';
+	CREATE TABLE run_sources (
+		run_id INTEGER NOT NULL REFERENCES runs(id),
+		source TEXT    NOT NULL,
+		failed INTEGER NOT NULL,
+		PRIMARY KEY (run_id, source)
+	);`,
+}
+
+// SQLiteStore is a Repository backed by a SQLite database file.
+type SQLiteStore struct {
+	db  *sql.DB
+	now func() time.Time
+}
+
+// Option configures a SQLiteStore.
+type Option func(*SQLiteStore)
+
+// WithClock overrides the clock used for first/last-seen timestamps.
+func WithClock(now func() time.Time) Option {
+	return func(s *SQLiteStore) {
+		s.now = now
+	}
+}
+
+// Open opens the database at path, creating it and its directory if needed,
+// and brings its schema up to date. Use ":memory:" for a throwaway database.
+func Open(path string, opts ...Option) (*SQLiteStore, error) {
+	if path != ":memory:" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, err
+		}
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLite allows a single writer; a single connection also keeps ":memory:" databases alive.
+	db.SetMaxOpenConns(1)
+
+	s := &SQLiteStore{db: db, now: time.Now}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if err := s.migrate(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrating %s: %w", path, err)
+	}
+
+	return s, nil
+}
+
+func (s *SQLiteStore) migrate() error {
+	if _, err := s.db.Exec(`PRAGMA foreign_keys = ON`); err != nil {
+		return err
+	}
+
+	var version int
+	if err := s.db.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil {
+		return err
+	}
+
+	for i := version; i < len(migrations); i++ {
+		tx, err := s.db.Begin()
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(migrations[i]); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if _, err := tx.Exec(fmt.Sprintf(`PRAGMA user_version = %d`, i+1)); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close closes the database.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+// RecordRun implements Repository.
+func (s *SQLiteStore) RecordRun(ctx context.Context, criteria model.SearchCriteria, sources []model.SourceRun) error {
+	now := s.now().Unix()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	searchID, err := upsertSearch(ctx, tx, criteria, now)
+	if err != nil {
+		return err
+	}
+
+	var previousID int64
+	err = tx.QueryRowContext(ctx, `SELECT id FROM runs WHERE search_id = ? ORDER BY id DESC LIMIT 1`, searchID).Scan(&previousID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	run, err := tx.ExecContext(ctx, `INSERT INTO runs (search_id, started_at) VALUES (?, ?)`, searchID, now)
+	if err != nil {
+		return err
+	}
+
+	runID, err := run.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	for _, src := range sources {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO run_sources (run_id, source, failed) VALUES (?, ?, ?) ON CONFLICT DO NOTHING`,
+			runID, src.Source, src.Failed)
+		if err != nil {
+			return err
+		}
+
+		for _, job := range src.Jobs {
+			if err := upsertJob(ctx, tx, searchID, runID, src.Source, job, now); err != nil {
+				return err
+			}
+		}
+
+		if src.Failed && previousID != 0 {
+			if err := keepJobs(ctx, tx, previousID, runID, src.Source); err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+// keepJobs copies the jobs source found in the previous run into the current one,
+// without refreshing their last-seen timestamp.
+func keepJobs(ctx context.Context, tx *sql.Tx, previousID, runID int64, source string) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO run_jobs (run_id, source, job_key, found_by)
+		SELECT ?, source, job_key, ? FROM run_jobs
+		WHERE run_id = ? AND (found_by = ? OR (found_by = '' AND source = ?))
+		ON CONFLICT DO NOTHING`,
+		runID, source, previousID, source, source)
+	return err
+}
+
+func upsertSearch(ctx context.Context, tx *sql.Tx, criteria model.SearchCriteria, now int64) (int64, error) {
+	key, err := searchKey(criteria)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO searches (criteria, created_at) VALUES (?, ?) ON CONFLICT(criteria) DO NOTHING`,
+		key, now)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int64
+	err = tx.QueryRowContext(ctx, `SELECT id FROM searches WHERE criteria = ?`, key).Scan(&id)
+	return id, err
+}
+
+func upsertJob(ctx context.Context, tx *sql.Tx, searchID, runID int64, foundBy string, job model.Job, now int64) error {
+	pay := ""
+	if !job.Pay.IsZero() {
+		b, err := json.Marshal(job.Pay)
+		if err != nil {
+			return err
+		}
+		pay = string(b)
+	}
+
+	tags := ""
+	if len(job.Tags) > 0 {
+		b, err := json.Marshal(job.Tags)
+		if err != nil {
+			return err
+		}
+		tags = string(b)
+	}
+
+	key := jobKey(job)
+
+	// Details found by an earlier enrichment are kept when this run didn't fetch them.
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO jobs (source, job_key, id, title, company, location, url, salary, description,
+			employment_type, department, workplace, apply_url, regions, tags, posted_at, expires_at, pay, first_seen_at, last_seen_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(source, job_key) DO UPDATE SET
+			id              = excluded.id,
+			title           = excluded.title,
+			company         = excluded.company,
+			location        = COALESCE(NULLIF(excluded.location, ''), jobs.location),
+			url             = excluded.url,
+			salary          = COALESCE(NULLIF(excluded.salary, ''), jobs.salary),
+			description     = COALESCE(NULLIF(excluded.description, ''), jobs.description),
+			employment_type = COALESCE(NULLIF(excluded.employment_type, ''), jobs.employment_type),
+			department      = COALESCE(NULLIF(excluded.department, ''), jobs.department),
+			workplace       = COALESCE(NULLIF(excluded.workplace, ''), jobs.workplace),
+			apply_url       = COALESCE(NULLIF(excluded.apply_url, ''), jobs.apply_url),
+			regions         = COALESCE(NULLIF(excluded.regions, ''), jobs.regions),
+			tags            = COALESCE(NULLIF(excluded.tags, ''), jobs.tags),
+			posted_at       = COALESCE(excluded.posted_at, jobs.posted_at),
+			expires_at      = COALESCE(excluded.expires_at, jobs.expires_at),
+			pay             = COALESCE(NULLIF(excluded.pay, ''), jobs.pay),
+			last_seen_at    = excluded.last_seen_at`,
+		job.Source, key, job.ID, job.Title, job.Company, job.Location, job.Url, job.Salary, job.Description,
+		job.EmploymentType, job.Department, job.Workplace, job.ApplyUrl, job.Regions, tags, unixTime(job.PostedAt), unixTime(job.ExpiresAt), pay, now, now)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO search_jobs (search_id, source, job_key, first_seen_at, last_seen_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(search_id, source, job_key) DO UPDATE SET last_seen_at = excluded.last_seen_at`,
+		searchID, job.Source, key, now, now)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO run_jobs (run_id, source, job_key, found_by) VALUES (?, ?, ?, ?) ON CONFLICT DO NOTHING`,
+		runID, job.Source, key, foundBy)
+	return err
+}
+
+// jobColumns are the columns read by scanJob.
+const jobColumns = `j.id, j.title, j.company, j.location, j.url, j.source, j.salary, j.description,
+	j.employment_type, j.department, j.workplace, j.apply_url, j.regions, j.tags, j.posted_at, j.expires_at, j.pay, j.first_seen_at, j.last_seen_at`
+
+// ListJobs implements Repository.
+func (s *SQLiteStore) ListJobs(ctx context.Context, filter Filter) ([]StoredJob, error) {
+	query := `SELECT ` + jobColumns + ` FROM jobs j`
+
+	var where []string
+	var args []any
+
+	if filter.Search != nil {
+		key, err := searchKey(*filter.Search)
+		if err != nil {
+			return nil, err
+		}
+		query += ` JOIN search_jobs sj ON sj.source = j.source AND sj.job_key = j.job_key
+			JOIN searches s ON s.id = sj.search_id`
+		where = append(where, `s.criteria = ?`)
+		args = append(args, key)
+	}
+
+	if filter.Source != "" {
+		where = append(where, `j.source = ?`)
+		args = append(args, filter.Source)
+	}
+
+	if !filter.SeenSince.IsZero() {
+		where = append(where, `j.last_seen_at >= ?`)
+		args = append(args, filter.SeenSince.Unix())
+	}
+
+	if len(where) > 0 {
+		query += ` WHERE ` + strings.Join(where, ` AND `)
+	}
+
+	query += ` ORDER BY j.last_seen_at DESC, j.first_seen_at DESC, j.rowid`
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(` LIMIT %d`, filter.Limit)
+	}
+
+	return s.queryJobs(ctx, query, args...)
+}
+
+// LastRun implements Repository. Jobs of a source that didn't take part in the
+// latest run are not reported as disappeared, nor are those of a failed source,
+// which RecordRun kept in the run.
+func (s *SQLiteStore) LastRun(ctx context.Context, criteria model.SearchCriteria) ([]RunJob, error) {
+	key, err := searchKey(criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT r.id FROM runs r JOIN searches s ON s.id = r.search_id
+		WHERE s.criteria = ? ORDER BY r.id DESC LIMIT 2`, key)
+	if err != nil {
+		return nil, err
+	}
+
+	var runIDs []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		runIDs = append(runIDs, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(runIDs) == 0 {
+		return nil, nil
+	}
+
+	latest, err := s.runJobs(ctx, runIDs[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var previous []runJob
+	if len(runIDs) > 1 {
+		if previous, err = s.runJobs(ctx, runIDs[1]); err != nil {
+			return nil, err
+		}
+	}
+
+	succeeded, err := s.runSources(ctx, runIDs[0])
+	if err != nil {
+		return nil, err
+	}
+	if succeeded == nil {
+		// Runs recorded before their sources were don't name them: the sources of their jobs succeeded.
+		succeeded = map[string]bool{}
+		for _, job := range latest {
+			succeeded[job.foundBy] = true
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, job := range previous {
+		seen[sourceKey(job.Job)] = true
+	}
+
+	found := map[string]bool{}
+	jobs := make([]RunJob, 0, len(latest))
+
+	for _, job := range latest {
+		k := sourceKey(job.Job)
+		found[k] = true
+
+		status := StatusNew
+		if seen[k] {
+			status = StatusOpen
+		}
+		jobs = append(jobs, RunJob{StoredJob: job.StoredJob, Status: status})
+	}
+
+	for _, job := range previous {
+		if succeeded[job.foundBy] && !found[sourceKey(job.Job)] {
+			jobs = append(jobs, RunJob{StoredJob: job.StoredJob, Status: StatusDisappeared})
+		}
+	}
+
+	return jobs, nil
+}
+
+// runJob is a job of a run along with the source that found it.
+type runJob struct {
+	StoredJob
+	foundBy string
+}
+
+// runJobs returns the jobs of a run in the order they were recorded.
+func (s *SQLiteStore) runJobs(ctx context.Context, runID int64) ([]runJob, error) {
+	stored, err := s.queryJobs(ctx, `SELECT `+jobColumns+` FROM run_jobs rj
+		JOIN jobs j ON j.source = rj.source AND j.job_key = rj.job_key
+		WHERE rj.run_id = ? ORDER BY rj.rowid`, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	foundBy := map[string]string{}
+	rows, err := s.db.QueryContext(ctx, `SELECT source, job_key, found_by FROM run_jobs WHERE run_id = ?`, runID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var source, key, by string
+		if err := rows.Scan(&source, &key, &by); err != nil {
+			return nil, err
+		}
+		if by == "" {
+			by = source
+		}
+		foundBy[source+"\x00"+key] = by
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	jobs := make([]runJob, len(stored))
+	for i, job := range stored {
+		jobs[i] = runJob{StoredJob: job, foundBy: foundBy[sourceKey(job.Job)]}
+	}
+	return jobs, nil
+}
+
+// runSources returns whether each source of a run succeeded, nil when the run doesn't name its sources.
+func (s *SQLiteStore) runSources(ctx context.Context, runID int64) (map[string]bool, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT source, failed FROM run_sources WHERE run_id = ?`, runID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sources map[string]bool
+	for rows.Next() {
+		var source string
+		var failed bool
+		if err := rows.Scan(&source, &failed); err != nil {
+			return nil, err
+		}
+		if sources == nil {
+			sources = map[string]bool{}
+		}
+		sources[source] = !failed
+	}
+	return sources, rows.Err()
+}
+
+// SaveSearch implements Repository. Saving under an existing name replaces that search.
+func (s *SQLiteStore) SaveSearch(ctx context.Context, name string, criteria model.SearchCriteria) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	searchID, err := upsertSearch(ctx, tx, criteria, s.now().Unix())
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(criteria)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO saved_searches (name, search_id, criteria) VALUES (?, ?, ?)
+		ON CONFLICT(name) DO UPDATE SET search_id = excluded.search_id, criteria = excluded.criteria`,
+		name, searchID, string(b))
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SavedSearch implements Repository.
+func (s *SQLiteStore) SavedSearch(ctx context.Context, name string) (model.SearchCriteria, error) {
+	var criteria model.SearchCriteria
+	var b string
+
+	err := s.db.QueryRowContext(ctx, `SELECT criteria FROM saved_searches WHERE name = ?`, name).Scan(&b)
+	if errors.Is(err, sql.ErrNoRows) {
+		return criteria, fmt.Errorf("saved search %q: %w", name, ErrNotFound)
+	}
+	if err != nil {
+		return criteria, err
+	}
+
+	err = json.Unmarshal([]byte(b), &criteria)
+	return criteria, err
+}
+
+func (s *SQLiteStore) queryJobs(ctx context.Context, query string, args ...any) ([]StoredJob, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []StoredJob
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, rows.Err()
+}
+
+func scanJob(rows *sql.Rows) (StoredJob, error) {
+	var job StoredJob
+	var postedAt, expiresAt sql.NullInt64
+	var pay, tags string
+	var firstSeen, lastSeen int64
+
+	err := rows.Scan(&job.ID, &job.Title, &job.Company, &job.Location, &job.Url, &job.Source, &job.Salary,
+		&job.Description, &job.EmploymentType, &job.Department, &job.Workplace, &job.ApplyUrl, &job.Regions, &tags, &postedAt, &expiresAt, &pay, &firstSeen, &lastSeen)
+	if err != nil {
+		return job, err
+	}
+
+	if postedAt.Valid {
+		job.PostedAt = time.Unix(postedAt.Int64, 0).UTC()
+	}
+	if expiresAt.Valid {
+		job.ExpiresAt = time.Unix(expiresAt.Int64, 0).UTC()
+	}
+
+	if pay != "" {
+		if err := json.Unmarshal([]byte(pay), &job.Pay); err != nil {
+			return job, err
+		}
+	}
+	if tags != "" {
+		if err := json.Unmarshal([]byte(tags), &job.Tags); err != nil {
+			return job, err
+		}
+	}
+
+	job.FirstSeen = time.Unix(firstSeen, 0).UTC()
+	job.LastSeen = time.Unix(lastSeen, 0).UTC()
+
+	return job, nil
+}
+
+// unixTime stores t as Unix seconds, or NULL when it is unknown.
+func unixTime(t time.Time) sql.NullInt64 {
+	if t.IsZero() {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: t.Unix(), Valid: true}
+}
+
+// jobKey identifies a job within its source: its ID when the scraper set one, its URL otherwise.
+func jobKey(job model.Job) string {
+	if job.ID != "" {
+		return job.ID
+	}
+	return job.Url
+}
+
+// sourceKey identifies a job across sources.
+func sourceKey(job model.Job) string {
+	return job.Source + "\x00" + jobKey(job)
+}
+
+// searchKey is the canonical form of a search used to recognize it across runs.
+func searchKey(criteria model.SearchCriteria) (string, error) {
+	criteria.Query = strings.ToLower(strings.TrimSpace(criteria.Query))
+	criteria.Location = strings.ToLower(strings.TrimSpace(criteria.Location))
+
+	b, err := json.Marshal(criteria)
+	return string(b), err
+}
@@ -0,0 +1,270 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clock is a settable time source for first/last-seen timestamps
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+func openTestStore(t *testing.T, c *clock) *SQLiteStore {
+	s, err := Open(":memory:", WithClock(c.Now))
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+// bySource groups jobs into successful runs of their sources
+func bySource(jobs []model.Job) []model.SourceRun {
+	var runs []model.SourceRun
+	index := map[string]int{}
+	for _, job := range jobs {
+		i, ok := index[job.Source]
+		if !ok {
+			i = len(runs)
+			index[job.Source] = i
+			runs = append(runs, model.SourceRun{Source: job.Source})
+		}
+		runs[i].Jobs = append(runs[i].Jobs, job)
+	}
+	return runs
+}
+
+// TestRecordRunTracksFirstAndLastSeen tests that re-seen jobs keep their first-seen time
+func TestRecordRunTracksFirstAndLastSeen(t *testing.T) {
+	c := &clock{now: time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)}
+	s := openTestStore(t, c)
+	ctx := context.Background()
+	criteria := model.SearchCriteria{Query: "golang", Location: "Berlin"}
+
+	require.NoError(t, s.RecordRun(ctx, criteria, bySource([]model.Job{
+		{ID: "1", Title: "Go Dev", Source: "Indeed", Description: "Full description"},
+		{Title: "Backend Engineer", Url: "https://example.com/2", Source: "LinkedIn"},
+	})))
+
+	c.now = c.now.Add(24 * time.Hour)
+	require.NoError(t, s.RecordRun(ctx, criteria, bySource([]model.Job{
+		{ID: "1", Title: "Senior Go Dev", Source: "Indeed"},
+	})))
+
+	jobs, err := s.ListJobs(ctx, Filter{})
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+
+	assert.Equal(t, "Senior Go Dev", jobs[0].Title)
+	assert.Equal(t, "Full description", jobs[0].Description, "details from earlier runs are kept")
+	assert.Equal(t, time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC), jobs[0].FirstSeen)
+	assert.Equal(t, time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC), jobs[0].LastSeen)
+
+	assert.Equal(t, "Backend Engineer", jobs[1].Title)
+	assert.Equal(t, jobs[1].FirstSeen, jobs[1].LastSeen)
+}
+
+// TestRecordRunRoundTrip tests that every job field survives storage
+func TestRecordRunRoundTrip(t *testing.T) {
+	s := openTestStore(t, &clock{now: time.Now()})
+	ctx := context.Background()
+
+	job := model.Job{
+		ID:             "abc",
+		Title:          "Go Dev",
+		Company:        "Corp",
+		Location:       "Remote",
+		Url:            "https://example.com/abc",
+		Source:         "Indeed",
+		Salary:         "$100K a year",
+		Description:    "Write Go",
+		EmploymentType: "Full-time",
+		Department:     "Engineering",
+		Workplace:      model.WorkplaceRemote,
+		ApplyUrl:       "https://example.com/abc/apply",
+		Regions:        "Europe",
+		Tags:           []string{"go", "backend"},
+		PostedAt:       time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC),
+		ExpiresAt:      time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC),
+		Pay:            model.Salary{Min: 100000, Max: 100000, Currency: "USD", Period: model.PeriodYear},
+	}
+
+	require.NoError(t, s.RecordRun(ctx, model.SearchCriteria{Query: "golang"}, bySource([]model.Job{job})))
+
+	jobs, err := s.ListJobs(ctx, Filter{})
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, job, jobs[0].Job)
+}
+
+// TestListJobsFilters tests filtering by search, source and last-seen time
+func TestListJobsFilters(t *testing.T) {
+	c := &clock{now: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)}
+	s := openTestStore(t, c)
+	ctx := context.Background()
+
+	golang := model.SearchCriteria{Query: "golang"}
+	rust := model.SearchCriteria{Query: "rust"}
+
+	require.NoError(t, s.RecordRun(ctx, golang, bySource([]model.Job{{ID: "1", Source: "Indeed"}, {ID: "2", Source: "LinkedIn"}})))
+	c.now = c.now.Add(time.Hour)
+	require.NoError(t, s.RecordRun(ctx, rust, bySource([]model.Job{{ID: "3", Source: "Indeed"}})))
+
+	jobs, err := s.ListJobs(ctx, Filter{Search: &model.SearchCriteria{Query: " GoLang "}})
+	require.NoError(t, err)
+	assert.Len(t, jobs, 2, "searches are matched case-insensitively")
+
+	jobs, err = s.ListJobs(ctx, Filter{Source: "Indeed"})
+	require.NoError(t, err)
+	assert.Len(t, jobs, 2)
+
+	jobs, err = s.ListJobs(ctx, Filter{SeenSince: c.now})
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "3", jobs[0].ID)
+
+	jobs, err = s.ListJobs(ctx, Filter{Limit: 1})
+	require.NoError(t, err)
+	assert.Len(t, jobs, 1)
+}
+
+// TestOpenCreatesDirectory tests that the database file and its directory are created, and reopened
+func TestOpenCreatesDirectory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "jobs.db")
+
+	s, err := Open(path)
+	require.NoError(t, err)
+	require.NoError(t, s.RecordRun(context.Background(), model.SearchCriteria{Query: "golang"}, bySource([]model.Job{{ID: "1", Source: "Indeed"}})))
+	require.NoError(t, s.Close())
+
+	s, err = Open(path)
+	require.NoError(t, err)
+	defer s.Close()
+
+	jobs, err := s.ListJobs(context.Background(), Filter{})
+	require.NoError(t, err)
+	assert.Len(t, jobs, 1)
+}
+
+// TestLastRunStatuses tests that jobs are marked new, open or disappeared against the previous run
+func TestLastRunStatuses(t *testing.T) {
+	c := &clock{now: time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)}
+	s := openTestStore(t, c)
+	ctx := context.Background()
+	criteria := model.SearchCriteria{Query: "golang"}
+
+	require.NoError(t, s.RecordRun(ctx, criteria, bySource([]model.Job{
+		{ID: "1", Source: "Indeed"},
+		{ID: "2", Source: "Indeed"},
+		{ID: "3", Source: "LinkedIn"},
+	})))
+
+	c.now = c.now.Add(24 * time.Hour)
+	require.NoError(t, s.RecordRun(ctx, model.SearchCriteria{Query: "rust"}, bySource([]model.Job{{ID: "9", Source: "Indeed"}})))
+
+	// LinkedIn failed on the second run, so its job is kept rather than reported as disappeared.
+	require.NoError(t, s.RecordRun(ctx, model.SearchCriteria{Query: "Golang"}, append(bySource([]model.Job{
+		{ID: "4", Source: "Indeed"},
+		{ID: "1", Source: "Indeed"},
+	}), model.SourceRun{Source: "LinkedIn", Failed: true})))
+
+	jobs, err := s.LastRun(ctx, criteria)
+	require.NoError(t, err)
+
+	statuses := map[string]Status{}
+	for _, job := range jobs {
+		statuses[job.ID] = job.Status
+	}
+
+	assert.Equal(t, map[string]Status{"4": StatusNew, "1": StatusOpen, "3": StatusOpen, "2": StatusDisappeared}, statuses)
+	assert.Equal(t, "4", jobs[0].ID, "jobs of the latest run keep their order")
+	assert.Equal(t, StatusDisappeared, jobs[len(jobs)-1].Status, "disappeared jobs come last")
+}
+
+// TestLastRunFailedAndEmptySources tests that a failed source keeps its jobs while
+// the jobs of a source that found nothing disappear
+func TestLastRunFailedAndEmptySources(t *testing.T) {
+	c := &clock{now: time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)}
+	s := openTestStore(t, c)
+	ctx := context.Background()
+	criteria := model.SearchCriteria{Query: "golang"}
+
+	require.NoError(t, s.RecordRun(ctx, criteria, []model.SourceRun{
+		{Source: "Indeed", Jobs: []model.Job{{ID: "1", Source: "Indeed"}}},
+		{Source: "LinkedIn", Jobs: []model.Job{{ID: "2", Source: "LinkedIn"}}},
+		{Source: "Feeds", Jobs: []model.Job{{ID: "3", Source: "Gopher Jobs"}}},
+	}))
+
+	statuses := func() map[string]Status {
+		jobs, err := s.LastRun(ctx, criteria)
+		require.NoError(t, err)
+
+		statuses := map[string]Status{}
+		for _, job := range jobs {
+			statuses[job.ID] = job.Status
+		}
+		return statuses
+	}
+
+	failed := []model.SourceRun{
+		{Source: "Indeed", Failed: true},
+		{Source: "LinkedIn"},
+		{Source: "Feeds"},
+	}
+
+	c.now = c.now.Add(24 * time.Hour)
+	require.NoError(t, s.RecordRun(ctx, criteria, failed), "runs without any job are recorded")
+	assert.Equal(t, map[string]Status{"1": StatusOpen, "2": StatusDisappeared, "3": StatusDisappeared}, statuses())
+
+	c.now = c.now.Add(24 * time.Hour)
+	require.NoError(t, s.RecordRun(ctx, criteria, failed))
+	assert.Equal(t, map[string]Status{"1": StatusOpen}, statuses(), "jobs are kept for as long as their source fails")
+
+	jobs, err := s.ListJobs(ctx, Filter{Source: "Indeed"})
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC), jobs[0].LastSeen, "kept jobs were not seen again")
+}
+
+// TestLastRunFirstRun tests that every job of a first run is new
+func TestLastRunFirstRun(t *testing.T) {
+	s := openTestStore(t, &clock{now: time.Now()})
+	ctx := context.Background()
+	criteria := model.SearchCriteria{Query: "golang"}
+
+	jobs, err := s.LastRun(ctx, criteria)
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+
+	require.NoError(t, s.RecordRun(ctx, criteria, bySource([]model.Job{{ID: "1", Source: "Indeed"}})))
+
+	jobs, err = s.LastRun(ctx, criteria)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, StatusNew, jobs[0].Status)
+}
+
+// TestSavedSearch tests saving, replacing and loading a named search
+func TestSavedSearch(t *testing.T) {
+	s := openTestStore(t, &clock{now: time.Now()})
+	ctx := context.Background()
+
+	_, err := s.SavedSearch(ctx, "daily")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, s.SaveSearch(ctx, "daily", model.SearchCriteria{Query: "golang"}))
+	require.NoError(t, s.SaveSearch(ctx, "daily", model.SearchCriteria{Query: "Golang", Workplace: model.WorkplaceRemote}))
+
+	criteria, err := s.SavedSearch(ctx, "daily")
+	require.NoError(t, err)
+	assert.Equal(t, model.SearchCriteria{Query: "Golang", Workplace: model.WorkplaceRemote}, criteria)
+}
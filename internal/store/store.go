@@ -0,0 +1,90 @@
+// Package store persists aggregated jobs in a local SQLite database so searches
+// survive the end of the program.
+package store
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/brandoyts/job-aggr/internal/model"
+)
+
+// ErrNotFound is returned when a saved search does not exist.
+var ErrNotFound = errors.New("not found")
+
+// Repository stores jobs and the searches that produced them.
+//
+//go:generate mockery --name=Repository --output=../mocks --outpkg=mocks --filename=repository_mock.go
+type Repository interface {
+	// RecordRun records a run of a search with what each source found, even when
+	// nothing was. New jobs are inserted, known ones are updated and have their
+	// last-seen timestamp refreshed. The jobs a failed source found in the previous
+	// run are kept in this one, as the source couldn't tell whether they are gone.
+	RecordRun(ctx context.Context, criteria model.SearchCriteria, sources []model.SourceRun) error
+
+	// ListJobs returns the stored jobs matching filter, most recently seen first.
+	ListJobs(ctx context.Context, filter Filter) ([]StoredJob, error)
+
+	// LastRun compares the latest run of a search with the run before it. It returns
+	// the jobs of the latest run, followed by the jobs that disappeared since the previous
+	// one: those a source that succeeded in the latest run no longer found.
+	LastRun(ctx context.Context, criteria model.SearchCriteria) ([]RunJob, error)
+
+	// SaveSearch gives a search a name it can be run by again later.
+	SaveSearch(ctx context.Context, name string, criteria model.SearchCriteria) error
+
+	// SavedSearch returns the criteria saved under name, or ErrNotFound.
+	SavedSearch(ctx context.Context, name string) (model.SearchCriteria, error)
+
+	Close() error
+}
+
+// Status tells how a job changed between two runs of the same search.
+type Status string
+
+const (
+	StatusNew         Status = "new"         // not found by the previous run
+	StatusOpen        Status = "open"        // found by the previous run as well
+	StatusDisappeared Status = "disappeared" // found by the previous run only
+)
+
+// Statuses lists every Status.
+var Statuses = []Status{StatusNew, StatusOpen, StatusDisappeared}
+
+// RunJob is a job of a search run along with how it changed since the previous run.
+type RunJob struct {
+	StoredJob
+	Status Status
+}
+
+// StoredJob is a job along with its history in the store.
+type StoredJob struct {
+	model.Job
+	FirstSeen time.Time
+	LastSeen  time.Time
+}
+
+// Filter narrows down ListJobs. Zero fields don't filter.
+type Filter struct {
+	Source    string                // e.g. "Indeed"
+	Search    *model.SearchCriteria // only jobs produced by this search
+	SeenSince time.Time             // only jobs seen at or after this time
+	Limit     int
+}
+
+// DefaultPath returns where the database is kept: $XDG_DATA_HOME/job-aggr/jobs.db,
+// falling back to ~/.local/share/job-aggr/jobs.db.
+func DefaultPath() (string, error) {
+	dir := os.Getenv("XDG_DATA_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dir, "job-aggr", "jobs.db"), nil
+}
@@ -48,6 +48,16 @@ func (f *InputField) Submit() {
 	f.model.Blur()
 }
 
+// SetValue prefills the field.
+func (f *InputField) SetValue(value string) {
+	f.model.SetValue(value)
+}
+
+// Reopen makes a submitted field editable again, keeping its value.
+func (f *InputField) Reopen() {
+	f.submitted = false
+}
+
 func (f InputField) View() string {
 	if f.submitted {
 		return f.label + " " + f.value
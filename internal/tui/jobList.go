@@ -2,7 +2,11 @@ package tui
 
 import (
 	"fmt"
+	"sort"
 
+	"github.com/brandoyts/job-aggr/internal/config"
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/store"
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -12,36 +16,59 @@ var baseStyle = lipgloss.NewStyle().
 	BorderForeground(lipgloss.Color("240"))
 
 type JobsList struct {
-	table   table.Model
-	jobs    []Job
-	visible bool
+	table     table.Model
+	items     []Job // in the order they were set
+	jobs      []Job // in the order they are displayed
+	sortByPay bool
+	visible   bool
 }
 
 type Job struct {
-	Title    string
-	Company  string
-	Location string
-	Link     string
-	Source   string
+	Title     string
+	Company   string
+	Location  string
+	Salary    string
+	AnnualPay float64 // yearly pay used for sorting, zero when unknown
+	Link      string
+	Source    string       // every source the job was found on, e.g. "Indeed, LinkedIn"
+	Status    store.Status // compared with the previous run of the search, empty when unknown
+
+	raw model.Job // the job as aggregated, used for exports
 }
 
 type OpenLinkMsg struct {
 	URL string
 }
 
-func NewJobsList() JobsList {
+// NewJobsList creates the jobs table. Column widths and the table height can be
+// overridden by the config file.
+func NewJobsList(layout config.TUI) JobsList {
 	columns := []table.Column{
+		{Title: "", Width: 5},
 		{Title: "Title", Width: 50},
 		{Title: "Company", Width: 25},
 		{Title: "Location", Width: 50},
-		{Title: "Source", Width: 20},
+		{Title: "Salary", Width: 25},
+		{Title: "Sources", Width: 20},
 		{Title: "Link", Width: 50},
 	}
 
+	// config.Columns names the columns in the same order.
+	for i, name := range config.Columns {
+		if width := layout.Columns[name]; width > 0 {
+			columns[i].Width = width
+		}
+	}
+
+	height := 20
+	if layout.TableHeight > 0 {
+		height = layout.TableHeight
+	}
+
 	t := table.New(
 		table.WithColumns(columns),
 		table.WithFocused(true),
-		table.WithHeight(20),
+		table.WithHeight(height),
 	)
 
 	s := table.DefaultStyles()
@@ -65,17 +92,46 @@ func NewJobsList() JobsList {
 }
 
 func (j *JobsList) SetItems(jobs []Job) {
-	j.jobs = jobs
+	j.items = jobs
 	j.visible = true
+	j.refresh()
+}
+
+// ToggleSortByPay switches between the original order and the highest paying jobs first.
+// Jobs without a known salary are listed last.
+func (j *JobsList) ToggleSortByPay() {
+	j.sortByPay = !j.sortByPay
+	j.refresh()
+}
+
+func (j *JobsList) refresh() {
+	j.jobs = append([]Job(nil), j.items...)
+
+	if j.sortByPay {
+		sort.SliceStable(j.jobs, func(a, b int) bool {
+			return j.jobs[a].AnnualPay > j.jobs[b].AnnualPay
+		})
+	}
 
-	rows := make([]table.Row, len(jobs))
-	for i, job := range jobs {
-		rows[i] = table.Row{job.Title, job.Company, job.Location, job.Source, job.Link}
+	rows := make([]table.Row, len(j.jobs))
+	for i, job := range j.jobs {
+		rows[i] = table.Row{badge(job.Status), job.Title, job.Company, job.Location, job.Salary, job.Source, job.Link}
 	}
 
 	j.table.SetRows(rows)
 }
 
+// badge is shown next to jobs that are new or gone since the previous run.
+func badge(status store.Status) string {
+	switch status {
+	case store.StatusNew:
+		return "NEW"
+	case store.StatusDisappeared:
+		return "GONE"
+	}
+	return ""
+}
+
 func (j *JobsList) Update(msg tea.Msg) tea.Cmd {
 	if !j.visible {
 		return nil
@@ -91,7 +147,12 @@ func (j JobsList) View() string {
 		return "\n📄 Job Results:\nNo jobs found.\n"
 	}
 
-	header := fmt.Sprintf("\n📄 Job Results: Found %d job(s)\n\n", len(j.jobs))
+	order := ""
+	if j.sortByPay {
+		order = ", highest pay first"
+	}
+
+	header := fmt.Sprintf("\n📄 Job Results: Found %d job(s)%s\n\n", len(j.jobs), order)
 	return header + baseStyle.Render(j.table.View()) + "\n"
 }
 
@@ -99,6 +160,15 @@ func (j JobsList) GetJobs() []Job {
 	return j.jobs
 }
 
+// GetModelJobs returns the jobs in the order they are displayed, as aggregated.
+func (j JobsList) GetModelJobs() []model.Job {
+	jobs := make([]model.Job, len(j.jobs))
+	for i, job := range j.jobs {
+		jobs[i] = job.raw
+	}
+	return jobs
+}
+
 func (j JobsList) GetSelected() Job {
 	selectedIdx := j.table.Cursor()
 	if selectedIdx < len(j.jobs) {
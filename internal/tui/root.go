@@ -2,33 +2,60 @@ package tui
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"os"
 	"strings"
 
+	"github.com/brandoyts/job-aggr/internal/config"
+	"github.com/brandoyts/job-aggr/internal/dedup"
+	"github.com/brandoyts/job-aggr/internal/export"
+	"github.com/brandoyts/job-aggr/internal/model"
 	"github.com/brandoyts/job-aggr/internal/service/aggregator"
-	"github.com/brandoyts/job-aggr/internal/service/scraper/indeed"
-	"github.com/brandoyts/job-aggr/internal/service/scraper/linkedin"
+	"github.com/brandoyts/job-aggr/internal/service/browser"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+	"github.com/brandoyts/job-aggr/internal/store"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 type Root struct {
+	pool        *browser.Pool
+	repo        store.Repository
+	cfg         config.Config
+	criteria    model.SearchCriteria
 	currentStep Step
 	title       InputField
 	location    InputField
+	exportPath  InputField
+	sources     SourcePicker
 	jobs        JobsList
 	progress    SearchProgress
+	events      <-chan aggregator.Event
+	cancel      context.CancelFunc
+	searchID    int
+	results     []Job
+	notice      string
 	err         error
 }
 
-func NewRoot() *Root {
+// NewRoot creates the TUI. Search results are saved to repo unless it is nil.
+func NewRoot(pool *browser.Pool, repo store.Repository, cfg config.Config) *Root {
 	title := NewInputField("Job Title:", "e.g. Software Engineer")
+	title.SetValue(cfg.Defaults.Query)
+
 	location := NewInputField("Location:", "e.g. San Francisco, CA")
+	location.SetValue(cfg.Defaults.Location)
 
 	return &Root{
+		pool:        pool,
+		repo:        repo,
+		cfg:         cfg,
 		currentStep: StepTitle,
 		title:       title,
 		location:    location,
-		jobs:        NewJobsList(),
+		sources:     NewSourcePicker(scraper.Sources(), scraper.Enabled(cfg), cfg),
+		jobs:        NewJobsList(cfg.TUI),
 		progress:    NewSearchProgress("🔎 Searching for jobs..."),
 	}
 }
@@ -42,13 +69,64 @@ func (m *Root) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		return m.handleKeyPress(msgTyped)
 
+	case searchMsg:
+		// Drop events of a search that has been canceled in the meantime.
+		if msgTyped.id != m.searchID {
+			return m, nil
+		}
+		return m.Update(msgTyped.msg)
+
 	case JobsMsg:
-		m.jobs.SetItems(msgTyped)
+		// A source finished: add its jobs to the table and wait for the next one.
+		m.results = append(m.results, msgTyped...)
+		m.jobs.SetItems(m.results)
+		return m, m.waitForEvent()
+
+	case ExportedMsg:
+		if msgTyped.Err != nil {
+			m.notice = "❌ Export failed: " + msgTyped.Err.Error()
+		} else {
+			m.notice = fmt.Sprintf("✅ Exported %d job(s) to %s", msgTyped.Jobs, msgTyped.Path)
+		}
+		return m, nil
+
+	case SearchDoneMsg:
+		m.finishSearch()
 		m.currentStep = StepJobs
+
+		// The final list has the jobs found on several sources merged.
+		if msgTyped.Jobs != nil {
+			m.results = toJobs(msgTyped.Jobs)
+			m.jobs.SetItems(m.results)
+		}
+		if msgTyped.StoreErr != nil {
+			m.notice = "⚠️ Jobs were not saved: " + msgTyped.StoreErr.Error()
+			return m, nil
+		}
+		return m, m.loadStatuses()
+
+	case StatusMsg:
+		if msgTyped.Err != nil {
+			m.notice = "⚠️ Could not compare with the previous run: " + msgTyped.Err.Error()
+			return m, nil
+		}
+		if len(msgTyped.Jobs) > 0 {
+			m.results = toRunJobs(msgTyped.Jobs)
+			m.jobs.SetItems(m.results)
+		}
 		return m, nil
 
 	case ErrMsg:
 		m.err = msgTyped
+
+		// Some sources failed: still render what they all returned, failed ones included.
+		var srcErr *aggregator.SourceError
+		if errors.As(msgTyped, &srcErr) {
+			m.jobs.SetItems(toJobs(srcErr.Jobs()))
+			m.finishSearch()
+			m.currentStep = StepJobs
+			return m, m.loadStatuses()
+		}
 		return m, nil
 
 	}
@@ -58,11 +136,40 @@ func (m *Root) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (m *Root) handleKeyPress(key tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch key.Type {
-	case tea.KeyCtrlC, tea.KeyEsc:
+	case tea.KeyEsc:
+		if m.currentStep == StepSearching {
+			return m, m.cancelSearch()
+		}
+		if m.currentStep == StepExport {
+			m.currentStep = StepJobs
+			return m, nil
+		}
+		if m.currentStep == StepSources {
+			m.location.Reopen()
+			m.currentStep = StepLocation
+			return m, m.location.Focus()
+		}
+		m.finishSearch()
+		return m, tea.Quit
+
+	case tea.KeyCtrlC:
+		m.finishSearch()
 		return m, tea.Quit
 
 	case tea.KeyEnter:
 		return m.handleEnter()
+
+	case tea.KeyRunes:
+		if m.currentStep == StepJobs && key.String() == "s" {
+			m.jobs.ToggleSortByPay()
+			return m, nil
+		}
+		if m.currentStep == StepJobs && key.String() == "e" {
+			m.notice = ""
+			m.exportPath = NewInputField("Export to:", "e.g. jobs.csv (csv, json, jsonl, md, html)")
+			m.currentStep = StepExport
+			return m, m.exportPath.Focus()
+		}
 	}
 
 	return m.updateCurrentField(key)
@@ -79,18 +186,65 @@ func (m *Root) handleEnter() (tea.Model, tea.Cmd) {
 
 	case StepLocation:
 		m.location.Submit()
+		m.currentStep = StepSources
+		return m, nil
+
+	case StepSources:
+		if !m.sources.IsValid() {
+			return m, nil
+		}
+
+		m.err = nil
+		if err := m.performSearch(); err != nil {
+			m.err = err
+			return m, nil
+		}
+
 		m.currentStep = StepSearching
 		m.progress = NewSearchProgress("🔎 Searching for jobs...")
-		return m, tea.Batch(m.progress.Init(), m.performSearch())
+		m.results = nil
+		return m, tea.Batch(m.progress.Init(), m.waitForEvent())
 
 	case StepJobs:
 		// Do nothing on Enter when viewing jobs
 		return m, nil
+
+	case StepExport:
+		if m.exportPath.IsValid() {
+			m.exportPath.Submit()
+			m.currentStep = StepJobs
+			return m, exportJobs(m.exportPath.Value(), m.jobs.GetModelJobs())
+		}
 	}
 
 	return m, nil
 }
 
+// cancelSearch stops the in-flight search and goes back to the title input,
+// keeping what was typed so the search can be adjusted.
+func (m *Root) cancelSearch() tea.Cmd {
+	m.finishSearch()
+	m.searchID++
+
+	m.results = nil
+	m.jobs = NewJobsList(m.cfg.TUI)
+	m.title.Reopen()
+	m.location.Reopen()
+	m.location.Blur()
+	m.currentStep = StepTitle
+
+	return m.title.Focus()
+}
+
+// finishSearch releases the resources of the current search, if any.
+func (m *Root) finishSearch() {
+	if m.cancel != nil {
+		m.cancel()
+		m.cancel = nil
+	}
+	m.events = nil
+}
+
 func (m *Root) updateCurrentField(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
@@ -99,10 +253,14 @@ func (m *Root) updateCurrentField(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmd = m.title.Update(msg)
 	case StepLocation:
 		cmd = m.location.Update(msg)
+	case StepSources:
+		cmd = m.sources.Update(msg)
 	case StepSearching:
 		cmd = m.progress.Update(msg)
 	case StepJobs:
 		cmd = m.jobs.Update(msg)
+	case StepExport:
+		cmd = m.exportPath.Update(msg)
 	}
 
 	return m, cmd
@@ -120,24 +278,42 @@ func (m Root) View() string {
 		b.WriteString("\n\n")
 	}
 
-	// Show progress during search
+	if m.currentStep == StepSources {
+		b.WriteString(m.sources.View())
+		b.WriteString("\n")
+	}
+
+	// Show progress during search, along with the jobs received so far
 	if m.currentStep == StepSearching {
 		b.WriteString(m.progress.View())
 		b.WriteString("\n\n")
+
+		if len(m.results) > 0 {
+			b.WriteString(m.jobs.View())
+		}
 	}
 
 	if m.currentStep >= StepJobs {
 		b.WriteString(m.jobs.View())
 	}
 
-	// Show instructions based on current step
-	if m.currentStep != StepSearching {
-		b.WriteString(m.getInstructions())
+	if m.currentStep == StepExport {
+		b.WriteString("\n")
+		b.WriteString(m.exportPath.View())
+		b.WriteString("\n\n")
 	}
 
+	if m.notice != "" {
+		b.WriteString(m.notice)
+		b.WriteString("\n")
+	}
+
+	// Show instructions based on current step
+	b.WriteString(m.getInstructions())
+
 	if m.err != nil {
 		b.WriteString("\n")
-		b.WriteString("Error: " + m.err.Error())
+		b.WriteString(m.errorView())
 	}
 
 	return b.String()
@@ -148,39 +324,206 @@ func (m Root) getInstructions() string {
 	case StepTitle:
 		return "(enter to continue, esc to quit)"
 	case StepLocation:
-		return "(enter to search, esc to quit)"
+		return "(enter to continue, esc to quit)"
+	case StepSources:
+		return "(↑/↓ to navigate, space to toggle, enter to search, esc to go back)"
 	case StepSearching:
-		return ""
+		return "(esc to cancel the search, ctrl+c to quit)"
 	case StepJobs:
-		return "(↑/↓ to navigate, esc to quit)"
+		return "(↑/↓ to navigate, s to sort by pay, e to export, esc to quit)"
+	case StepExport:
+		return "(enter to export, esc to go back)"
 	}
 	return ""
 }
 
-func (m Root) performSearch() tea.Cmd {
+func (m Root) errorView() string {
+	var srcErr *aggregator.SourceError
+	if !errors.As(m.err, &srcErr) {
+		return "Error: " + m.err.Error()
+	}
+
+	var b strings.Builder
+	for _, report := range srcErr.Failed() {
+		b.WriteString(fmt.Sprintf("⚠️  %s failed after %.1fs: %v%s\n", report.Source, report.Duration.Seconds(), report.Err, failureHint(report.Err)))
+	}
+	return b.String()
+}
+
+// failureHint suggests what the user can do about a scraper error.
+func failureHint(err error) string {
+	switch {
+	case errors.Is(err, scraper.ErrLaunch):
+		return " (is Chrome/Chromium installed?)"
+	case errors.Is(err, scraper.ErrBlocked):
+		return " (the site is blocking automated access, try again later)"
+	case errors.Is(err, scraper.ErrNavigationTimeout):
+		return " (the site is slow or unreachable)"
+	case errors.Is(err, scraper.ErrSelectorNotFound):
+		return " (the site layout may have changed)"
+	}
+	return ""
+}
+
+// exportJobs writes jobs to path, in the format matching its extension.
+func exportJobs(path string, jobs []model.Job) tea.Cmd {
 	return func() tea.Msg {
-		in := indeed.NewScraper()
-		li := linkedin.NewScraper()
+		exporter, err := export.ForFile(path)
+		if err != nil {
+			return ExportedMsg{Path: path, Err: err}
+		}
 
-		aggr := aggregator.NewAggregatorService(in, li)
+		f, err := os.Create(path)
+		if err != nil {
+			return ExportedMsg{Path: path, Err: err}
+		}
+
+		if err := exporter.Export(f, jobs); err != nil {
+			f.Close()
+			return ExportedMsg{Path: path, Err: err}
+		}
+
+		return ExportedMsg{Path: path, Jobs: len(jobs), Err: f.Close()}
+	}
+}
 
-		result, err := aggr.FetchJobs(context.Background(), m.title.Value(), m.location.Value())
+// performSearch starts streaming jobs from the selected sources. The search runs until
+// it completes or is canceled through m.cancel.
+func (m *Root) performSearch() error {
+	var scrapers []aggregator.JobScraper
+	for _, name := range m.sources.Selected() {
+		s, err := scraper.New(name, m.pool, m.cfg.Source(name))
 		if err != nil {
-			return ErrMsg(err)
+			return err
 		}
+		scrapers = append(scrapers, s)
+	}
+
+	opts := []aggregator.Option{aggregator.WithPolicy(aggregator.BestEffort), aggregator.WithDedup()}
+	if m.repo != nil {
+		opts = append(opts, aggregator.WithRepository(m.repo))
+	}
+
+	aggr := aggregator.NewAggregatorServiceWithOptions(scrapers, opts...)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	m.criteria = model.SearchCriteria{
+		Query:    m.title.Value(),
+		Location: m.location.Value(),
+	}
 
-		var jobs []Job
-		for _, job := range result {
-			jobs = append(jobs, Job{
-				Title:    job.Title,
-				Company:  job.Company,
-				Location: job.Location,
-				Source:   job.Source,
-				Link:     job.Url,
-			})
+	m.searchID++
+	m.cancel = cancel
+	m.events = aggr.Stream(ctx, m.criteria)
+
+	return nil
+}
+
+// loadStatuses compares the search that just finished with its previous run,
+// so the table can flag new and disappeared jobs.
+func (m Root) loadStatuses() tea.Cmd {
+	if m.repo == nil {
+		return nil
+	}
+
+	repo, criteria, id := m.repo, m.criteria, m.searchID
+	return func() tea.Msg {
+		jobs, err := repo.LastRun(context.Background(), criteria)
+		return searchMsg{id: id, msg: StatusMsg{Jobs: jobs, Err: err}}
+	}
+}
+
+// waitForEvent turns the next streamed event into a message: a JobsMsg for every
+// finished source, then a SearchDoneMsg or ErrMsg once the search completes.
+func (m Root) waitForEvent() tea.Cmd {
+	events := m.events
+	if events == nil {
+		return nil
+	}
+
+	id := m.searchID
+	return func() tea.Msg {
+		event, ok := <-events
+		if !ok {
+			return searchMsg{id: id, msg: SearchDoneMsg{}}
+		}
+		return searchMsg{id: id, msg: eventMsg(event)}
+	}
+}
+
+func eventMsg(event aggregator.Event) tea.Msg {
+	if !event.Done {
+		return JobsMsg(toJobs(event.Jobs))
+	}
+
+	if event.Err != nil {
+		return ErrMsg(event.Err)
+	}
+
+	// Partial failure: the error carries the jobs, including those failed sources returned before failing.
+	if err := event.Result.Err(); err != nil {
+		return ErrMsg(err)
+	}
+
+	return SearchDoneMsg{Jobs: event.Result.Jobs, StoreErr: event.Result.StoreErr}
+}
+
+func toJobs(result []model.Job) []Job {
+	var jobs []Job
+	for _, job := range result {
+		salary := job.Salary
+		if salary == "" {
+			salary = job.Pay.String()
+		}
+
+		jobs = append(jobs, Job{
+			Title:     job.Title,
+			Company:   job.Company,
+			Location:  job.Location,
+			Salary:    salary,
+			AnnualPay: job.Pay.AnnualMidpoint(),
+			Source:    strings.Join(job.Sources(), ", "),
+			Link:      job.Url,
+			raw:       job,
+		})
+	}
+	return jobs
+}
+
+// toRunJobs converts the jobs of a run, merging duplicates like the search did.
+// A merged job is open if any of its listings is, and new if the others are gone.
+func toRunJobs(result []store.RunJob) []Job {
+	statuses := map[model.JobLink]store.Status{}
+	raw := make([]model.Job, len(result))
+	for i, job := range result {
+		raw[i] = job.Job
+		statuses[model.JobLink{Source: job.Source, ID: job.ID, Url: job.Url}] = job.Status
+	}
+
+	merged := dedup.Merge(raw)
+	jobs := toJobs(merged)
+
+	for i, job := range merged {
+		links := job.Links
+		if len(links) == 0 {
+			links = []model.JobLink{{Source: job.Source, ID: job.ID, Url: job.Url}}
+		}
+
+		jobs[i].Status = store.StatusDisappeared
+		for _, link := range links {
+			switch statuses[link] {
+			case store.StatusOpen:
+				jobs[i].Status = store.StatusOpen
+			case store.StatusNew:
+				if jobs[i].Status == store.StatusDisappeared {
+					jobs[i].Status = store.StatusNew
+				}
+			}
 		}
-		return JobsMsg(jobs)
 	}
+
+	return jobs
 }
 
 // Getters for accessing state
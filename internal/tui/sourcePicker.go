@@ -0,0 +1,115 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/brandoyts/job-aggr/internal/config"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// SourcePicker lets the user choose which of the registered sources to search.
+// Sources that need settings the config file doesn't give, like the companies to
+// read from, are listed as not configured and can't be picked.
+type SourcePicker struct {
+	sources  []scraper.Source
+	selected map[string]bool
+	unusable map[string]bool
+	cursor   int
+}
+
+// NewSourcePicker lists sources with the enabled ones selected.
+func NewSourcePicker(sources []scraper.Source, enabled []string, cfg config.Config) SourcePicker {
+	selected := map[string]bool{}
+	for _, name := range enabled {
+		selected[name] = true
+	}
+
+	unusable := map[string]bool{}
+	for _, s := range sources {
+		if !s.Usable(cfg.Source(s.Name)) {
+			unusable[s.Name] = true
+		}
+	}
+
+	return SourcePicker{
+		sources:  sources,
+		selected: selected,
+		unusable: unusable,
+	}
+}
+
+// Update moves the cursor with the arrow keys (or j/k) and toggles a source with space.
+func (p *SourcePicker) Update(msg tea.Msg) tea.Cmd {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return nil
+	}
+
+	switch key.String() {
+	case "up", "k":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case "down", "j":
+		if p.cursor < len(p.sources)-1 {
+			p.cursor++
+		}
+	case " ", "x":
+		if p.cursor < len(p.sources) {
+			if name := p.sources[p.cursor].Name; !p.unusable[name] {
+				p.selected[name] = !p.selected[name]
+			}
+		}
+	}
+
+	return nil
+}
+
+// Selected returns the names of the selected sources, in the order they are listed.
+func (p SourcePicker) Selected() []string {
+	var names []string
+	for _, s := range p.sources {
+		if p.selected[s.Name] {
+			names = append(names, s.Name)
+		}
+	}
+	return names
+}
+
+func (p SourcePicker) IsValid() bool {
+	return len(p.Selected()) > 0
+}
+
+func (p SourcePicker) View() string {
+	var b strings.Builder
+	b.WriteString("Sources:\n")
+
+	for i, s := range p.sources {
+		cursor := "  "
+		if i == p.cursor {
+			cursor = "> "
+		}
+
+		check := "[ ]"
+		switch {
+		case p.unusable[s.Name]:
+			check = "[-]"
+		case p.selected[s.Name]:
+			check = "[x]"
+		}
+
+		line := fmt.Sprintf("%s%s %s", cursor, check, s.Label)
+		if capabilities := s.Capabilities.List(); len(capabilities) > 0 {
+			line += " (" + strings.Join(capabilities, ", ") + ")"
+		}
+		if p.unusable[s.Name] {
+			line += " (not configured)"
+		}
+
+		b.WriteString(line + "\n")
+	}
+
+	return b.String()
+}
@@ -1,19 +1,53 @@
 package tui
 
+import (
+	"github.com/brandoyts/job-aggr/internal/model"
+	"github.com/brandoyts/job-aggr/internal/store"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
 type Step int
 
 const (
 	StepAPIKey Step = iota
 	StepTitle
 	StepLocation
+	StepSources
 	StepSearching
 	StepJobs
+	StepExport
 )
 
 type (
 	JobsMsg []Job
 	ErrMsg  error
 
+	// SearchDoneMsg is sent once every source has finished, with the final list of jobs.
+	// StoreErr is set when the jobs could not be saved to the local store.
+	SearchDoneMsg struct {
+		Jobs     []model.Job
+		StoreErr error
+	}
+
+	// StatusMsg carries the jobs of the finished search compared with its previous run.
+	StatusMsg struct {
+		Jobs []store.RunJob
+		Err  error
+	}
+
+	// ExportedMsg reports the outcome of writing the results to a file.
+	ExportedMsg struct {
+		Path string
+		Jobs int
+		Err  error
+	}
+
+	// searchMsg tags a search message with the search that produced it.
+	searchMsg struct {
+		id  int
+		msg tea.Msg
+	}
+
 	DoneMsg struct {
 		APIKey   string
 		Title    string
@@ -1,18 +1,84 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"os"
+	"os/signal"
 
+	"github.com/brandoyts/job-aggr/internal/cli"
+	"github.com/brandoyts/job-aggr/internal/config"
+	"github.com/brandoyts/job-aggr/internal/service/browser"
+	"github.com/brandoyts/job-aggr/internal/service/scraper"
+	_ "github.com/brandoyts/job-aggr/internal/service/scraper/sources" // registers every bundled source
+	"github.com/brandoyts/job-aggr/internal/service/scraper/spec"
+	"github.com/brandoyts/job-aggr/internal/store"
 	"github.com/brandoyts/job-aggr/internal/tui"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 func main() {
-	p := tea.NewProgram(tui.NewRoot())
-	_, err := p.Run()
+	// Non-interactive mode for scripts and cron, e.g. job-aggr search --query golang --format json
+	if len(os.Args) > 1 && os.Args[1] == "search" {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		code := cli.Search(ctx, os.Args[2:], os.Stdout, os.Stderr)
+		stop()
+		os.Exit(code)
+	}
+
+	fs := flag.NewFlagSet("job-aggr", flag.ExitOnError)
+	configPath := fs.String("config", "", "config file, defaults to $"+config.EnvPath+" or job-aggr/config.yaml in the user config dir")
+	fs.Parse(os.Args[1:])
+
+	// Settings are validated before anything starts, so mistakes are reported right away.
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(2)
+	}
+
+	// One browser is shared by every scraper and every search.
+	pool := browser.NewPool(cfg.Browser.Options())
+
+	// Searches are saved locally; the TUI still works without the store.
+	var repo store.Repository
+	db, err := openStore()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Warning: jobs will not be saved:", err)
+	} else {
+		repo = db
+	}
+
+	p := tea.NewProgram(tui.NewRoot(pool, repo, cfg))
+	_, err = p.Run()
+	pool.Close()
+	if db != nil {
+		db.Close()
+	}
 	if err != nil {
 		fmt.Println("Error:", err)
 		os.Exit(1)
 	}
 }
+
+func loadConfig(flagPath string) (config.Config, error) {
+	path, explicit, err := config.Path(flagPath)
+	if err != nil {
+		return config.Config{}, err
+	}
+
+	// Sources described by spec files must be known before the config file names them.
+	if err := spec.RegisterDir(config.SpecsDir(path)); err != nil {
+		return config.Config{}, err
+	}
+	return config.Load(path, explicit, scraper.Names())
+}
+
+func openStore() (*store.SQLiteStore, error) {
+	path, err := store.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return store.Open(path)
+}